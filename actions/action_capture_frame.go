@@ -0,0 +1,73 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/merzzzl/screen-flow/device"
+)
+
+type ActionCaptureFrame struct {
+	Path string
+	Var  string
+}
+
+func (s *ActionCaptureFrame) Handle(ctx context.Context, conn *device.Conn) error {
+	if err := conn.CheckVision(); err != nil {
+		return fmt.Errorf("need vision: %w, %w", ErrNoClints, err)
+	}
+
+	img, err := conn.GetVision().Screenshot(ctx)
+	if err != nil {
+		return fmt.Errorf("screenshot: %w", err)
+	}
+
+	if s.Var != "" {
+		VarsFromContext(ctx).Set(s.Var, img)
+	}
+
+	if s.Path == "" {
+		return nil
+	}
+
+	path, err := VarsFromContext(ctx).Expand(s.Path)
+	if err != nil {
+		return fmt.Errorf("capture frame: %w", err)
+	}
+
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("create dir: %w", err)
+		}
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jpg", ".jpeg":
+		err = jpeg.Encode(file, img, &jpeg.Options{Quality: 90})
+	default:
+		err = png.Encode(file, img)
+	}
+
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+
+	if err != nil {
+		return fmt.Errorf("encode frame: %w", err)
+	}
+
+	RecordFromContext(ctx).AddScreenshot(path)
+	LoggerFromContext(ctx, conn).Debug("frame captured", "path", path)
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/merzzzl/screen-flow/device"
+)
+
+type ActionSetClipboard struct {
+	Text  string
+	Paste bool
+}
+
+type ActionGetClipboard struct {
+	Var     string
+	CopyKey byte
+}
+
+type ActionAssertClipboard struct {
+	Regexp string
+}
+
+func (s *ActionSetClipboard) Handle(ctx context.Context, conn *device.Conn) error {
+	if err := conn.CheckSCRCPY(); err != nil {
+		return fmt.Errorf("need scrcpy: %w, %w", ErrNoClints, err)
+	}
+
+	text, err := VarsFromContext(ctx).Expand(s.Text)
+	if err != nil {
+		return fmt.Errorf("set clipboard: %w", err)
+	}
+
+	if err := conn.GetSCRCPY().SetClipboardWait(ctx, text, s.Paste); err != nil {
+		return fmt.Errorf("set clipboard: %w", err)
+	}
+
+	LoggerFromContext(ctx, conn).Debug("set clipboard", "length", len(text), "paste", s.Paste)
+
+	return nil
+}
+
+func (s *ActionGetClipboard) Handle(ctx context.Context, conn *device.Conn) error {
+	if err := conn.CheckSCRCPY(); err != nil {
+		return fmt.Errorf("need scrcpy: %w, %w", ErrNoClints, err)
+	}
+
+	text, err := conn.GetSCRCPY().GetClipboard(ctx, s.CopyKey)
+	if err != nil {
+		return fmt.Errorf("get clipboard: %w", err)
+	}
+
+	VarsFromContext(ctx).Set(s.Var, text)
+	LoggerFromContext(ctx, conn).Debug("get clipboard", "var", s.Var, "length", len(text))
+
+	return nil
+}
+
+func (s *ActionAssertClipboard) Handle(ctx context.Context, conn *device.Conn) error {
+	if err := conn.CheckSCRCPY(); err != nil {
+		return fmt.Errorf("need scrcpy: %w, %w", ErrNoClints, err)
+	}
+
+	expr, err := VarsFromContext(ctx).ExpandRegexp(s.Regexp)
+	if err != nil {
+		return fmt.Errorf("assert clipboard: %w", err)
+	}
+
+	rx, err := regexp.Compile(expr)
+	if err != nil {
+		return fmt.Errorf("compile regexp: %w", err)
+	}
+
+	text, err := conn.GetSCRCPY().GetClipboard(ctx, 0)
+	if err != nil {
+		return fmt.Errorf("get clipboard: %w", err)
+	}
+
+	if !rx.MatchString(text) {
+		return fmt.Errorf("assert clipboard %q: %w", text, ErrClipboardMismatch)
+	}
+
+	return nil
+}
@@ -22,10 +22,14 @@ type ActionKey struct {
 
 func (s *ActionKey) Handle(ctx context.Context, conn *device.Conn) error {
 	if conn.CheckABG() == nil {
+		LoggerFromContext(ctx, conn).Debug("key", "backend", "accessibility-bridge", "press", s.Press)
+
 		return s.abg(ctx, conn)
 	}
 
 	if conn.CheckSCRCPY() == nil {
+		LoggerFromContext(ctx, conn).Debug("key", "backend", "scrcpy", "press", s.Press)
+
 		return s.scrcpy(conn)
 	}
 
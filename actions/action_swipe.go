@@ -3,6 +3,7 @@ package actions
 import (
 	"context"
 	"fmt"
+	"image"
 	"time"
 
 	abg "github.com/merzzzl/accessibility-bridge-go"
@@ -20,10 +21,14 @@ type ActionSwipe struct {
 
 func (s *ActionSwipe) Handle(ctx context.Context, conn *device.Conn) error {
 	if conn.CheckABG() == nil {
+		LoggerFromContext(ctx, conn).Debug("swipe", "backend", "accessibility-bridge", "from", image.Pt(s.X1, s.Y1), "to", image.Pt(s.X2, s.Y2))
+
 		return s.abg(ctx, conn)
 	}
 
 	if conn.CheckSCRCPY() == nil {
+		LoggerFromContext(ctx, conn).Debug("swipe", "backend", "scrcpy", "from", image.Pt(s.X1, s.Y1), "to", image.Pt(s.X2, s.Y2))
+
 		return s.scrcpy(conn)
 	}
 
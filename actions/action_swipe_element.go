@@ -22,13 +22,25 @@ func (s *ActionSwipeElement) Handle(ctx context.Context, conn *device.Conn) erro
 		return fmt.Errorf("need accessibility-bridge: %w, %w", ErrNoClints, err)
 	}
 
-	err := conn.GetABG().PerformSwipe(ctx, &abg.ActionSwipe{
+	vars := VarsFromContext(ctx)
+
+	uniqueID, err := vars.Expand(s.UniqueID)
+	if err != nil {
+		return fmt.Errorf("swipe element: %w", err)
+	}
+
+	expr, err := vars.ExpandRegexp(s.Regexp)
+	if err != nil {
+		return fmt.Errorf("swipe element: %w", err)
+	}
+
+	err = conn.GetABG().PerformSwipe(ctx, &abg.ActionSwipe{
 		Finger: &abg.Finger{
 			FingerId: 1,
 			Start: &abg.Finger_StartElement{
 				StartElement: &abg.ElementSelector{
-					UniqueId: s.UniqueID,
-					Regex:    s.Regexp,
+					UniqueId: uniqueID,
+					Regex:    expr,
 				},
 			},
 			Width:    int32(s.W),
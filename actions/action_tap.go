@@ -14,14 +14,34 @@ type ActionTap struct {
 	X        int
 	Y        int
 	Duration time.Duration
+	Var      string
 }
 
 func (s *ActionTap) Handle(ctx context.Context, conn *device.Conn) error {
+	if s.Var != "" {
+		point, ok := VarsFromContext(ctx).Point(s.Var)
+		if !ok {
+			return fmt.Errorf("tap %q: %w", s.Var, ErrVarNotFound)
+		}
+
+		nextStep := ActionTap{
+			X:        point.X,
+			Y:        point.Y,
+			Duration: s.Duration,
+		}
+
+		return nextStep.Handle(ctx, conn)
+	}
+
 	if conn.CheckABG() == nil {
+		LoggerFromContext(ctx, conn).Debug("tap", "backend", "accessibility-bridge", "x", s.X, "y", s.Y)
+
 		return s.abg(ctx, conn)
 	}
 
 	if conn.CheckSCRCPY() == nil {
+		LoggerFromContext(ctx, conn).Debug("tap", "backend", "scrcpy", "x", s.X, "y", s.Y)
+
 		return s.scrcpy(conn)
 	}
 
@@ -66,4 +86,4 @@ func (s *ActionTap) abg(ctx context.Context, conn *device.Conn) error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
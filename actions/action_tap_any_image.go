@@ -0,0 +1,46 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"time"
+
+	"github.com/merzzzl/screen-flow/device"
+)
+
+type ActionTapAnyImage struct {
+	ImageTemplates []image.Image
+	Duration       time.Duration
+	SearchArea     *image.Rectangle
+}
+
+func (s *ActionTapAnyImage) Handle(ctx context.Context, conn *device.Conn) error {
+	if err := conn.CheckVision(); err != nil {
+		return fmt.Errorf("need vision: %w, %w", ErrNoClints, err)
+	}
+
+	startAt := time.Now()
+
+	index, match, err := conn.GetVision().FindAny(ctx, s.ImageTemplates...)
+	if err != nil {
+		return fmt.Errorf("find point: %w", err)
+	}
+
+	point := match.Point
+
+	RecordFromContext(ctx).SetMatch(s.ImageTemplates[index], match)
+	LoggerFromContext(ctx, conn).Debug("template found", "index", index, "point", point, "took", time.Since(startAt))
+
+	if s.SearchArea != nil && !point.In(*s.SearchArea) {
+		return ErrImageNotFound
+	}
+
+	nextStep := ActionTap{
+		X:        point.X,
+		Y:        point.Y,
+		Duration: s.Duration,
+	}
+
+	return nextStep.Handle(ctx, conn)
+}
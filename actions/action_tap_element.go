@@ -20,11 +20,23 @@ func (s *ActionTapElement) Handle(ctx context.Context, conn *device.Conn) error
 		return fmt.Errorf("need accessibility-bridge: %w, %w", ErrNoClints, err)
 	}
 
-	err := conn.GetABG().PerformClick(ctx, &abg.ActionClick{
+	vars := VarsFromContext(ctx)
+
+	uniqueID, err := vars.Expand(s.UniqueID)
+	if err != nil {
+		return fmt.Errorf("tap element: %w", err)
+	}
+
+	expr, err := vars.ExpandRegexp(s.Regexp)
+	if err != nil {
+		return fmt.Errorf("tap element: %w", err)
+	}
+
+	err = conn.GetABG().PerformClick(ctx, &abg.ActionClick{
 		Click: &abg.ActionClick_ClickElement{
 			ClickElement: &abg.ElementSelector{
-				UniqueId: s.UniqueID,
-				Regex:    s.Regexp,
+				UniqueId: uniqueID,
+				Regex:    expr,
 			},
 		},
 		Duration: int32(s.Duration),
@@ -20,11 +20,18 @@ func (s *ActionTapImage) Handle(ctx context.Context, conn *device.Conn) error {
 		return fmt.Errorf("need vision: %w, %w", ErrNoClints, err)
 	}
 
-	point, err := conn.GetVision().Find(ctx, s.ImageTemplate)
+	startAt := time.Now()
+
+	match, err := conn.GetVision().FindMatch(ctx, s.ImageTemplate)
 	if err != nil {
 		return fmt.Errorf("find point: %w", err)
 	}
 
+	point := match.Point
+
+	RecordFromContext(ctx).SetMatch(s.ImageTemplate, match)
+	LoggerFromContext(ctx, conn).Debug("template found", "point", point, "took", time.Since(startAt))
+
 	if s.SearchArea == nil {
 		nextStep := ActionTap{
 			X: point.X,
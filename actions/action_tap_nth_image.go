@@ -0,0 +1,63 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"time"
+
+	"github.com/merzzzl/screen-flow/device"
+	"github.com/merzzzl/screen-flow/vision"
+)
+
+type ActionTapNthImage struct {
+	ImageTemplate image.Image
+	Index         int
+	Threshold     float64
+	Duration      time.Duration
+	SearchArea    *image.Rectangle
+}
+
+func (s *ActionTapNthImage) Handle(ctx context.Context, conn *device.Conn) error {
+	if err := conn.CheckVision(); err != nil {
+		return fmt.Errorf("need vision: %w, %w", ErrNoClints, err)
+	}
+
+	startAt := time.Now()
+
+	all, err := conn.GetVision().FindAll(ctx, s.ImageTemplate, s.Threshold)
+	if err != nil {
+		return fmt.Errorf("find points: %w", err)
+	}
+
+	matches := make([]*vision.Match, 0, len(all))
+
+	for _, m := range all {
+		if s.SearchArea == nil || m.Point.In(*s.SearchArea) {
+			matches = append(matches, m)
+		}
+	}
+
+	index := s.Index
+	if index < 0 {
+		index += len(matches)
+	}
+
+	if index < 0 || index >= len(matches) {
+		return fmt.Errorf("occurrence %d of %d: %w", s.Index, len(matches), ErrImageNotFound)
+	}
+
+	match := matches[index]
+	point := match.Point
+
+	RecordFromContext(ctx).SetMatch(s.ImageTemplate, match)
+	LoggerFromContext(ctx, conn).Debug("template found", "index", index, "count", len(matches), "point", point, "took", time.Since(startAt))
+
+	nextStep := ActionTap{
+		X:        point.X,
+		Y:        point.Y,
+		Duration: s.Duration,
+	}
+
+	return nextStep.Handle(ctx, conn)
+}
@@ -12,27 +12,36 @@ type ActionType struct {
 }
 
 func (s *ActionType) Handle(ctx context.Context, conn *device.Conn) error {
+	payload, err := VarsFromContext(ctx).Expand(s.Payload)
+	if err != nil {
+		return fmt.Errorf("type: %w", err)
+	}
+
 	if conn.CheckABG() == nil {
-		return s.abg(ctx, conn)
+		LoggerFromContext(ctx, conn).Debug("type", "backend", "accessibility-bridge", "length", len(payload))
+
+		return s.abg(ctx, conn, payload)
 	}
 
 	if conn.CheckSCRCPY() == nil {
-		return s.scrcpy(conn)
+		LoggerFromContext(ctx, conn).Debug("type", "backend", "scrcpy", "length", len(payload))
+
+		return s.scrcpy(conn, payload)
 	}
 
 	return fmt.Errorf("need accessibility-bridge or scrcpy: %w", ErrNoClints)
 }
 
-func (s *ActionType) scrcpy(conn *device.Conn) error {
-	if err := conn.GetSCRCPY().InjectText(s.Payload); err != nil {
+func (s *ActionType) scrcpy(conn *device.Conn, payload string) error {
+	if err := conn.GetSCRCPY().InjectText(payload); err != nil {
 		return fmt.Errorf("inject action: %w", err)
 	}
 
 	return nil
 }
 
-func (s *ActionType) abg(ctx context.Context, conn *device.Conn) error {
-	if err := conn.GetABG().TypeText(ctx, s.Payload); err != nil {
+func (s *ActionType) abg(ctx context.Context, conn *device.Conn, payload string) error {
+	if err := conn.GetABG().TypeText(ctx, payload); err != nil {
 		return fmt.Errorf("inject action: %w", err)
 	}
 
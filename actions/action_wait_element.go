@@ -14,6 +14,7 @@ type ActionWaitElement struct {
 	Regexp   string
 	UniqueID string
 	Duration *time.Duration
+	Var      string
 }
 
 func (s *ActionWaitElement) Handle(ctx context.Context, conn *device.Conn) error {
@@ -21,6 +22,27 @@ func (s *ActionWaitElement) Handle(ctx context.Context, conn *device.Conn) error
 		return fmt.Errorf("need accessibility-bridge: %w, %w", ErrNoClints, err)
 	}
 
+	vars := VarsFromContext(ctx)
+
+	uniqueID, err := vars.Expand(s.UniqueID)
+	if err != nil {
+		return fmt.Errorf("wait element: %w", err)
+	}
+
+	expr, err := vars.ExpandRegexp(s.Regexp)
+	if err != nil {
+		return fmt.Errorf("wait element: %w", err)
+	}
+
+	var rx *regexp.Regexp
+
+	if expr != "" {
+		rx, err = regexp.Compile(expr)
+		if err != nil {
+			return fmt.Errorf("compile regexp: %w", err)
+		}
+	}
+
 	startAt := time.Now()
 
 	for {
@@ -29,7 +51,15 @@ func (s *ActionWaitElement) Handle(ctx context.Context, conn *device.Conn) error
 			return fmt.Errorf("inject action: %w", err)
 		}
 
-		if s.check(dump) {
+		if view := findElement(dump, uniqueID, rx); view != nil {
+			RecordFromContext(ctx).SetElement(view)
+			recordFrame(ctx, conn)
+			LoggerFromContext(ctx, conn).Debug("element found", "text", view.GetText(), "unique_id", view.GetUniqueId(), "took", time.Since(startAt))
+
+			if s.Var != "" {
+				vars.Set(s.Var, view.GetText())
+			}
+
 			return nil
 		}
 
@@ -43,31 +73,26 @@ func (s *ActionWaitElement) Handle(ctx context.Context, conn *device.Conn) error
 	}
 }
 
-func (s *ActionWaitElement) check(dump *abg.ScreenView) bool {
+func findElement(dump *abg.ScreenView, uniqueID string, rx *regexp.Regexp) *abg.ScreenView {
 	ok := true
 
-	if s.UniqueID != "" && ok {
-		ok = dump.GetUniqueId() == s.UniqueID
+	if uniqueID != "" && ok {
+		ok = dump.GetUniqueId() == uniqueID
 	}
 
-	if s.Regexp != "" && ok {
-		rx, err := regexp.Compile(s.Regexp)
-		if err != nil {
-			return false
-		}
-
+	if rx != nil && ok {
 		ok = rx.MatchString(dump.GetText())
 	}
 
 	if ok {
-		return true
+		return dump
 	}
 
 	for _, c := range dump.GetChildren() {
-		if s.check(c) {
-			return true
+		if view := findElement(c, uniqueID, rx); view != nil {
+			return view
 		}
 	}
 
-	return false
+	return nil
 }
@@ -13,6 +13,7 @@ type ActionWaitImage struct {
 	ImageTemplate image.Image
 	Duration      *time.Duration
 	SearchArea    *image.Rectangle
+	Var           string
 }
 
 func (s *ActionWaitImage) Handle(ctx context.Context, conn *device.Conn) error {
@@ -20,28 +21,40 @@ func (s *ActionWaitImage) Handle(ctx context.Context, conn *device.Conn) error {
 		return fmt.Errorf("need vision: %w, %w", ErrNoClints, err)
 	}
 
+	waitCtx := ctx
+
+	if s.Duration != nil {
+		var cancel context.CancelFunc
+
+		waitCtx, cancel = context.WithTimeout(ctx, *s.Duration)
+		defer cancel()
+	}
+
 	startAt := time.Now()
 
 	for {
-		point, err := conn.GetVision().Find(ctx, s.ImageTemplate)
+		match, err := conn.GetVision().FindMatch(waitCtx, s.ImageTemplate)
 		if err != nil {
+			if ctx.Err() == nil && waitCtx.Err() != nil {
+				return fmt.Errorf("find point: %w", ErrImageNotFound)
+			}
+
 			return fmt.Errorf("find point: %w", err)
 		}
 
-		if s.SearchArea == nil {
-			return nil
-		}
+		point := match.Point
+
+		if s.SearchArea == nil || point.In(*s.SearchArea) {
+			RecordFromContext(ctx).SetMatch(s.ImageTemplate, match)
+			LoggerFromContext(ctx, conn).Debug("template found", "point", point, "took", time.Since(startAt))
+
+			if s.Var != "" {
+				VarsFromContext(ctx).Set(s.Var, point)
+			}
 
-		if point.In(*s.SearchArea) {
 			return nil
 		}
 
 		time.Sleep(time.Microsecond * 200)
-
-		if s.Duration != nil {
-			if time.Now().After(startAt.Add(*s.Duration)) {
-				return fmt.Errorf("find point: %w", ErrImageNotFound)
-			}
-		}
 	}
 }
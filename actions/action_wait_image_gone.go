@@ -0,0 +1,51 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"time"
+
+	"github.com/merzzzl/screen-flow/device"
+)
+
+type ActionWaitImageGone struct {
+	ImageTemplate image.Image
+	Frames        int
+	Duration      *time.Duration
+	SearchArea    *image.Rectangle
+}
+
+func (s *ActionWaitImageGone) Handle(ctx context.Context, conn *device.Conn) error {
+	if err := conn.CheckVision(); err != nil {
+		return fmt.Errorf("need vision: %w, %w", ErrNoClints, err)
+	}
+
+	frames := s.Frames
+	if frames == 0 {
+		frames = 5
+	}
+
+	waitCtx := ctx
+
+	if s.Duration != nil {
+		var cancel context.CancelFunc
+
+		waitCtx, cancel = context.WithTimeout(ctx, *s.Duration)
+		defer cancel()
+	}
+
+	startAt := time.Now()
+
+	if err := conn.GetVision().WaitGone(waitCtx, s.ImageTemplate, s.SearchArea, frames); err != nil {
+		if ctx.Err() == nil && waitCtx.Err() != nil {
+			return fmt.Errorf("wait gone: %w", ErrImageStillVisible)
+		}
+
+		return fmt.Errorf("wait gone: %w", err)
+	}
+
+	LoggerFromContext(ctx, conn).Debug("template gone", "frames", frames, "took", time.Since(startAt))
+
+	return nil
+}
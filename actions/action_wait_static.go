@@ -0,0 +1,49 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/merzzzl/screen-flow/device"
+)
+
+type ActionWaitStatic struct {
+	Threshold   float64
+	MinDuration time.Duration
+	Duration    *time.Duration
+}
+
+func (s *ActionWaitStatic) Handle(ctx context.Context, conn *device.Conn) error {
+	if err := conn.CheckVision(); err != nil {
+		return fmt.Errorf("need vision: %w, %w", ErrNoClints, err)
+	}
+
+	threshold := s.Threshold
+	if threshold == 0 {
+		threshold = 0.10
+	}
+
+	waitCtx := ctx
+
+	if s.Duration != nil {
+		var cancel context.CancelFunc
+
+		waitCtx, cancel = context.WithTimeout(ctx, *s.Duration)
+		defer cancel()
+	}
+
+	startAt := time.Now()
+
+	if err := conn.GetVision().WaitStable(waitCtx, threshold, s.MinDuration); err != nil {
+		if ctx.Err() == nil && waitCtx.Err() != nil {
+			return fmt.Errorf("wait static: %w", ErrScreenNotStatic)
+		}
+
+		return fmt.Errorf("wait static: %w", err)
+	}
+
+	LoggerFromContext(ctx, conn).Debug("screen static", "threshold", threshold, "took", time.Since(startAt))
+
+	return nil
+}
@@ -4,3 +4,7 @@ import "errors"
 
 var ErrNoClints = errors.New("action supported client not found")
 var ErrImageNotFound = errors.New("image not found")
+var ErrImageStillVisible = errors.New("image still visible")
+var ErrScreenNotStatic = errors.New("screen not static")
+var ErrClipboardMismatch = errors.New("clipboard mismatch")
+var ErrVarNotFound = errors.New("variable not found")
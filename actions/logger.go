@@ -0,0 +1,22 @@
+package actions
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/merzzzl/screen-flow/device"
+)
+
+type loggerKey struct{}
+
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+func LoggerFromContext(ctx context.Context, conn *device.Conn) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+
+	return conn.Logger()
+}
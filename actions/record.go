@@ -0,0 +1,104 @@
+package actions
+
+import (
+	"context"
+	"image"
+
+	abg "github.com/merzzzl/accessibility-bridge-go"
+	"github.com/merzzzl/screen-flow/device"
+	"github.com/merzzzl/screen-flow/vision"
+)
+
+type Record struct {
+	Point       *image.Point
+	Match       *vision.Match
+	Template    image.Image
+	Element     *Element
+	Frame       image.Image
+	Screenshots []string
+}
+
+type Element struct {
+	Text      string
+	UniqueID  string
+	ClassName string
+	Bounds    image.Rectangle
+}
+
+type recordKey struct{}
+
+func WithRecord(ctx context.Context, record *Record) context.Context {
+	return context.WithValue(ctx, recordKey{}, record)
+}
+
+func RecordFromContext(ctx context.Context) *Record {
+	if record, ok := ctx.Value(recordKey{}).(*Record); ok {
+		return record
+	}
+
+	return nil
+}
+
+func (r *Record) SetPoint(point image.Point) {
+	if r == nil {
+		return
+	}
+
+	r.Point = &point
+}
+
+func (r *Record) SetMatch(tmpl image.Image, match *vision.Match) {
+	if r == nil || match == nil {
+		return
+	}
+
+	r.SetPoint(match.Point)
+	r.Match = match
+	r.Template = tmpl
+
+	if match.Frame != nil {
+		r.Frame = match.Frame
+	}
+}
+
+func (r *Record) SetFrame(img image.Image) {
+	if r == nil || img == nil {
+		return
+	}
+
+	r.Frame = img
+}
+
+func (r *Record) SetElement(view *abg.ScreenView) {
+	if r == nil || view == nil {
+		return
+	}
+
+	b := view.GetBounds()
+
+	r.Element = &Element{
+		Text:      view.GetText(),
+		UniqueID:  view.GetUniqueId(),
+		ClassName: view.GetClassName(),
+		Bounds:    image.Rect(int(b.GetLeft()), int(b.GetTop()), int(b.GetRight()), int(b.GetBottom())),
+	}
+}
+
+func (r *Record) AddScreenshot(path string) {
+	if r == nil {
+		return
+	}
+
+	r.Screenshots = append(r.Screenshots, path)
+}
+
+func recordFrame(ctx context.Context, conn *device.Conn) {
+	record := RecordFromContext(ctx)
+	if record == nil || conn.CheckVision() != nil {
+		return
+	}
+
+	if img, _, err := conn.GetVision().LatestFrame(); err == nil {
+		record.SetFrame(img)
+	}
+}
@@ -0,0 +1,168 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"maps"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+type Vars struct {
+	mu     sync.RWMutex
+	values map[string]any
+}
+
+type varsKey struct{}
+
+func NewVars() *Vars {
+	return &Vars{
+		values: make(map[string]any),
+	}
+}
+
+func WithVars(ctx context.Context, vars *Vars) context.Context {
+	return context.WithValue(ctx, varsKey{}, vars)
+}
+
+func VarsFromContext(ctx context.Context) *Vars {
+	if vars, ok := ctx.Value(varsKey{}).(*Vars); ok {
+		return vars
+	}
+
+	return nil
+}
+
+func (v *Vars) Set(name string, value any) {
+	if v == nil {
+		return
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.values[name] = value
+}
+
+func (v *Vars) Get(name string) (any, bool) {
+	if v == nil {
+		return nil, false
+	}
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	value, ok := v.values[name]
+
+	return value, ok
+}
+
+func VarAs[T any](v *Vars, name string) (T, bool) {
+	var zero T
+
+	value, ok := v.Get(name)
+	if !ok {
+		return zero, false
+	}
+
+	out, ok := value.(T)
+	if !ok {
+		return zero, false
+	}
+
+	return out, true
+}
+
+func (v *Vars) Delete(name string) {
+	if v == nil {
+		return
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	delete(v.values, name)
+}
+
+func (v *Vars) String(name string) (string, bool) {
+	value, ok := v.Get(name)
+	if !ok {
+		return "", false
+	}
+
+	if str, ok := value.(string); ok {
+		return str, true
+	}
+
+	return fmt.Sprint(value), true
+}
+
+func (v *Vars) Point(name string) (image.Point, bool) {
+	return VarAs[image.Point](v, name)
+}
+
+func (v *Vars) Snapshot() map[string]any {
+	if v == nil {
+		return nil
+	}
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	return maps.Clone(v.values)
+}
+
+func (v *Vars) Expand(s string) (string, error) {
+	return v.expand(s, func(value string) string { return value })
+}
+
+func (v *Vars) ExpandRegexp(s string) (string, error) {
+	return v.expand(s, regexp.QuoteMeta)
+}
+
+func (v *Vars) expand(s string, quote func(string) string) (string, error) {
+	if !strings.Contains(s, "${") {
+		return s, nil
+	}
+
+	var out strings.Builder
+
+	for {
+		start := strings.Index(s, "${")
+		if start < 0 {
+			break
+		}
+
+		if start > 0 && s[start-1] == '$' {
+			out.WriteString(s[:start-1])
+			out.WriteString("${")
+
+			s = s[start+2:]
+
+			continue
+		}
+
+		end := strings.IndexByte(s[start:], '}')
+		if end < 0 {
+			break
+		}
+
+		name := s[start+2 : start+end]
+
+		value, ok := v.String(name)
+		if !ok {
+			return "", fmt.Errorf("expand %q: %w", name, ErrVarNotFound)
+		}
+
+		out.WriteString(s[:start])
+		out.WriteString(quote(value))
+
+		s = s[start+end+1:]
+	}
+
+	out.WriteString(s)
+
+	return out.String(), nil
+}
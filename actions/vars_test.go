@@ -0,0 +1,117 @@
+package actions
+
+import (
+	"errors"
+	"image"
+	"testing"
+)
+
+func TestVarsExpand(t *testing.T) {
+	vars := NewVars()
+	vars.Set("user", "alice")
+	vars.Set("otp", 1234)
+	vars.Set("price", "1.50$")
+
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		regexp  string
+		wantErr error
+	}{
+		{name: "no placeholders", in: "hello", want: "hello", regexp: "hello"},
+		{name: "single", in: "${user}", want: "alice", regexp: "alice"},
+		{name: "surrounded", in: "hi ${user}!", want: "hi alice!", regexp: "hi alice!"},
+		{name: "non-string value", in: "code ${otp}", want: "code 1234", regexp: "code 1234"},
+		{name: "several", in: "${user}:${otp}", want: "alice:1234", regexp: "alice:1234"},
+		{name: "escaped", in: "$${user}", want: "${user}", regexp: "${user}"},
+		{name: "escaped then expanded", in: "$${user} ${user}", want: "${user} alice", regexp: "${user} alice"},
+		{name: "unterminated", in: "${user", want: "${user", regexp: "${user"},
+		{name: "quoted in regexp", in: "^${price}$", want: "^1.50$$", regexp: `^1\.50\$$`},
+		{name: "unknown", in: "${missing}", wantErr: ErrVarNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := vars.Expand(tt.in)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("Expand() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("Expand() = %q, want %q", got, tt.want)
+			}
+
+			got, err = vars.ExpandRegexp(tt.in)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("ExpandRegexp() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.regexp {
+				t.Errorf("ExpandRegexp() = %q, want %q", got, tt.regexp)
+			}
+		})
+	}
+}
+
+func TestVarsExpandNil(t *testing.T) {
+	var vars *Vars
+
+	if got, err := vars.Expand("plain"); err != nil || got != "plain" {
+		t.Errorf("Expand() = %q, %v, want plain, nil", got, err)
+	}
+
+	if _, err := vars.Expand("${user}"); !errors.Is(err, ErrVarNotFound) {
+		t.Errorf("Expand() error = %v, want %v", err, ErrVarNotFound)
+	}
+}
+
+func TestVarAs(t *testing.T) {
+	vars := NewVars()
+	vars.Set("point", image.Pt(3, 4))
+	vars.Set("name", "alice")
+
+	tests := []struct {
+		name  string
+		check func() (any, bool)
+		want  any
+		ok    bool
+	}{
+		{
+			name:  "point",
+			check: func() (any, bool) { return VarAs[image.Point](vars, "point") },
+			want:  image.Pt(3, 4),
+			ok:    true,
+		},
+		{
+			name:  "string",
+			check: func() (any, bool) { return VarAs[string](vars, "name") },
+			want:  "alice",
+			ok:    true,
+		},
+		{
+			name:  "wrong type",
+			check: func() (any, bool) { return VarAs[int](vars, "name") },
+			want:  0,
+		},
+		{
+			name:  "missing",
+			check: func() (any, bool) { return VarAs[string](vars, "missing") },
+			want:  "",
+		},
+		{
+			name:  "nil store",
+			check: func() (any, bool) { return VarAs[string](nil, "name") },
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.check()
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("VarAs() = %v, %v, want %v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
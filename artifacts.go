@@ -0,0 +1,189 @@
+package screenflow
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/merzzzl/screen-flow/device"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+type artifacts struct {
+	dir        string
+	prefix     string
+	conn       *device.Conn
+	last       error
+	screenshot string
+	screenDump string
+}
+
+type artifactsKey struct{}
+
+type framesKey struct{}
+
+func (f *Flow) WithArtifacts(dir string) *Flow {
+	f.artifacts = dir
+
+	return f
+}
+
+func (f *Flow) WithStepFrames() *Flow {
+	f.frames = true
+
+	return f
+}
+
+func (f *Flow) bindArtifacts(ctx context.Context, conn *device.Conn) context.Context {
+	if f.frames {
+		ctx = context.WithValue(ctx, framesKey{}, conn)
+	}
+
+	if f.artifacts == "" {
+		return ctx
+	}
+
+	a := &artifacts{
+		dir:  f.artifacts,
+		conn: conn,
+	}
+
+	if f.name != "" {
+		a.prefix = artifactName(f.name) + "_"
+	}
+
+	return context.WithValue(ctx, artifactsKey{}, a)
+}
+
+func (a *artifacts) capture(path string, stepErr error) (string, string, error) {
+	if a == nil {
+		return "", "", nil
+	}
+
+	if a.last != nil && errors.Is(stepErr, a.last) {
+		return a.screenshot, a.screenDump, nil
+	}
+
+	a.last = stepErr
+	a.screenshot, a.screenDump = "", ""
+
+	if err := os.MkdirAll(a.dir, 0o755); err != nil {
+		return "", "", fmt.Errorf("create artifacts dir: %w", err)
+	}
+
+	var errs [2]error
+
+	name := a.prefix + artifactName(path)
+	a.screenshot, errs[0] = a.writeScreenshot(name)
+	a.screenDump, errs[1] = a.writeScreenDump(name)
+
+	return a.screenshot, a.screenDump, errors.Join(errs[:]...)
+}
+
+func (a *artifacts) writeScreenshot(name string) (string, error) {
+	if a.conn.CheckVision() != nil {
+		return "", nil
+	}
+
+	img, _, err := a.conn.GetVision().LatestFrame()
+	if err != nil {
+		return "", fmt.Errorf("latest frame: %w", err)
+	}
+
+	path := filepath.Join(a.dir, name+".png")
+
+	file, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("create file: %w", err)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	if err := png.Encode(file, img); err != nil {
+		return "", fmt.Errorf("encode png: %w", err)
+	}
+
+	return path, nil
+}
+
+func (a *artifacts) writeScreenDump(name string) (string, error) {
+	if a.conn.CheckABG() != nil {
+		return "", nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	dump, err := a.conn.GetABG().ScreenDump(ctx)
+	if err != nil {
+		return "", fmt.Errorf("screen dump: %w", err)
+	}
+
+	data, err := protojson.MarshalOptions{Multiline: true}.Marshal(dump)
+	if err != nil {
+		return "", fmt.Errorf("marshal screen dump: %w", err)
+	}
+
+	path := filepath.Join(a.dir, name+".json")
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("write file: %w", err)
+	}
+
+	return path, nil
+}
+
+func (a *artifacts) writeFrame(path string, frame []byte) (string, error) {
+	if a == nil || frame == nil {
+		return "", nil
+	}
+
+	if err := os.MkdirAll(a.dir, 0o755); err != nil {
+		return "", fmt.Errorf("create artifacts dir: %w", err)
+	}
+
+	name := filepath.Join(a.dir, a.prefix+artifactName(path)+".frame.jpg")
+
+	if err := os.WriteFile(name, frame, 0o644); err != nil {
+		return "", fmt.Errorf("write file: %w", err)
+	}
+
+	return name, nil
+}
+
+func captureFrame(conn *device.Conn, img image.Image) ([]byte, error) {
+	if img == nil {
+		if conn == nil || conn.CheckVision() != nil {
+			return nil, nil
+		}
+
+		latest, _, err := conn.GetVision().LatestFrame()
+		if err != nil {
+			return nil, fmt.Errorf("latest frame: %w", err)
+		}
+
+		img = latest
+	}
+
+	var buf bytes.Buffer
+
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 75}); err != nil {
+		return nil, fmt.Errorf("encode jpeg: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func artifactName(path string) string {
+	return strings.NewReplacer(" > ", "_", " ", "-", "/", "-").Replace(path)
+}
@@ -0,0 +1,49 @@
+package screenflow
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/merzzzl/screen-flow/device"
+)
+
+type ifAction struct {
+	cond      Condition
+	then      []FlowStep
+	otherwise []FlowStep
+}
+
+func (a *ifAction) Handle(ctx context.Context, conn *device.Conn) error {
+	ok, err := a.cond.Check(ctx, conn)
+	if err != nil {
+		return fmt.Errorf("check condition: %w", err)
+	}
+
+	branch, steps := "then", a.then
+
+	if !ok {
+		branch, steps = "else", a.otherwise
+	}
+
+	return runSteps(ctx, conn, branch, steps)
+}
+
+func (*ifAction) kind() string {
+	return "If"
+}
+
+func (*ifAction) composite() {}
+
+func If(cond Condition, then, otherwise []FlowStep) FlowStep {
+	return &ifAction{
+		cond:      cond,
+		then:      then,
+		otherwise: otherwise,
+	}
+}
+
+func (f *Flow) If(cond Condition, then, otherwise []FlowStep) *Flow {
+	f.steps = append(f.steps, If(cond, then, otherwise))
+
+	return f
+}
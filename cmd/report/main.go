@@ -0,0 +1,107 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/merzzzl/screen-flow/report"
+)
+
+func main() {
+	format := flag.String("format", "text", "output format: text, junit, json or html")
+	output := flag.String("o", "", "output file (default stdout)")
+	strict := flag.Bool("strict", false, "exit with status 1 if any flow failed")
+
+	flag.Parse()
+
+	rep, err := load(flag.Args())
+	if err != nil {
+		log.Fatalf("load reports: %v", err)
+	}
+
+	if err := save(*output, rep, *format); err != nil {
+		log.Fatalf("write report: %v", err)
+	}
+
+	if *strict && !rep.Passed() {
+		os.Exit(1)
+	}
+}
+
+func save(output string, rep *report.Report, format string) error {
+	if output == "" {
+		return write(os.Stdout, rep, format)
+	}
+
+	file, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("create output: %w", err)
+	}
+
+	if err := write(file, rep, format); err != nil {
+		_ = file.Close()
+
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("close output: %w", err)
+	}
+
+	return nil
+}
+
+func load(files []string) (*report.Report, error) {
+	if len(files) == 0 {
+		return report.Read(os.Stdin)
+	}
+
+	reports := make([]*report.Report, 0, len(files))
+
+	for _, name := range files {
+		rep, err := loadFile(name)
+		if err != nil {
+			return nil, err
+		}
+
+		reports = append(reports, rep)
+	}
+
+	return report.Merge(reports...), nil
+}
+
+func loadFile(name string) (*report.Report, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", name, err)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	rep, err := report.Read(file)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", name, err)
+	}
+
+	return rep, nil
+}
+
+func write(w io.Writer, rep *report.Report, format string) error {
+	switch format {
+	case "text":
+		return rep.WriteText(w)
+	case "junit":
+		return rep.WriteJUnit(w)
+	case "json":
+		return rep.WriteJSON(w)
+	case "html":
+		return rep.WriteHTML(w)
+	default:
+		return fmt.Errorf("format %q: %w", format, report.ErrUnknownFormat)
+	}
+}
@@ -0,0 +1,72 @@
+package screenflow
+
+import (
+	"context"
+	"errors"
+	"image"
+	"time"
+
+	"github.com/merzzzl/screen-flow/actions"
+	"github.com/merzzzl/screen-flow/device"
+)
+
+type Condition interface {
+	Check(ctx context.Context, conn *device.Conn) (bool, error)
+}
+
+type ConditionFunc func(ctx context.Context, conn *device.Conn) (bool, error)
+
+func (fn ConditionFunc) Check(ctx context.Context, conn *device.Conn) (bool, error) {
+	return fn(ctx, conn)
+}
+
+func ImageVisible(img image.Image, area *image.Rectangle, within time.Duration) Condition {
+	return ConditionFunc(func(ctx context.Context, conn *device.Conn) (bool, error) {
+		step := &actions.ActionWaitImage{
+			ImageTemplate: img,
+			SearchArea:    area,
+			Duration:      &within,
+		}
+
+		return checkStep(ctx, conn, step)
+	})
+}
+
+func ElementExists(regexp, uniqid string, within time.Duration) Condition {
+	return ConditionFunc(func(ctx context.Context, conn *device.Conn) (bool, error) {
+		step := &actions.ActionWaitElement{
+			Regexp:   regexp,
+			UniqueID: uniqid,
+			Duration: &within,
+		}
+
+		return checkStep(ctx, conn, step)
+	})
+}
+
+func Predicate(fn func(ctx context.Context, conn *device.Conn) bool) Condition {
+	return ConditionFunc(func(ctx context.Context, conn *device.Conn) (bool, error) {
+		return fn(ctx, conn), nil
+	})
+}
+
+func Not(cond Condition) Condition {
+	return ConditionFunc(func(ctx context.Context, conn *device.Conn) (bool, error) {
+		ok, err := cond.Check(ctx, conn)
+
+		return !ok, err
+	})
+}
+
+func checkStep(ctx context.Context, conn *device.Conn, step FlowStep) (bool, error) {
+	err := step.Handle(actions.WithRecord(ctx, nil), conn)
+	if errors.Is(err, actions.ErrImageNotFound) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,86 @@
+package screenflow
+
+import (
+	"context"
+	"time"
+
+	"github.com/merzzzl/screen-flow/device"
+)
+
+type delayAction struct {
+	step   FlowStep
+	before time.Duration
+	after  time.Duration
+}
+
+func (a *delayAction) Handle(ctx context.Context, conn *device.Conn) error {
+	if err := sleep(ctx, a.before); err != nil {
+		return err
+	}
+
+	if err := a.step.Handle(ctx, conn); err != nil {
+		return err
+	}
+
+	return sleep(ctx, a.after)
+}
+
+func (a *delayAction) unwrap() FlowStep {
+	return a.step
+}
+
+func DelayBefore(step FlowStep, dur time.Duration) FlowStep {
+	return &delayAction{
+		step:   step,
+		before: dur,
+	}
+}
+
+func DelayAfter(step FlowStep, dur time.Duration) FlowStep {
+	return &delayAction{
+		step:  step,
+		after: dur,
+	}
+}
+
+func (f *Flow) DelayBefore(dur time.Duration) *Flow {
+	if len(f.steps) == 0 {
+		return f
+	}
+
+	f.steps[len(f.steps)-1] = DelayBefore(f.steps[len(f.steps)-1], dur)
+
+	return f
+}
+
+func (f *Flow) DelayAfter(dur time.Duration) *Flow {
+	if len(f.steps) == 0 {
+		return f
+	}
+
+	f.steps[len(f.steps)-1] = DelayAfter(f.steps[len(f.steps)-1], dur)
+
+	return f
+}
+
+func (f *Flow) WithPacing(dur time.Duration) *Flow {
+	f.pacing = dur
+
+	return f
+}
+
+func sleep(ctx context.Context, dur time.Duration) error {
+	if dur <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(dur)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -17,7 +17,7 @@ func (c *ABG) ScreenDump(ctx context.Context) (*abg.ScreenView, error) {
 		return nil, fmt.Errorf("conn: %w", err)
 	}
 
-	out, err := c.conn.abg.ScreenDump(ctx, &emptypb.Empty{})
+	out, err := c.conn.abg.ScreenDump(ctx, &emptypb.Empty{}, c.conn.callOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("abg: %w", err)
 	}
@@ -30,7 +30,7 @@ func (c *ABG) PerformSwipe(ctx context.Context, action *abg.ActionSwipe) error {
 		return fmt.Errorf("conn: %w", err)
 	}
 
-	_, err := c.conn.abg.PerformSwipe(ctx, action)
+	_, err := c.conn.abg.PerformSwipe(ctx, action, c.conn.callOptions()...)
 	if err != nil {
 		return fmt.Errorf("abg: %w", err)
 	}
@@ -43,7 +43,7 @@ func (c *ABG) PerformMultiTouch(ctx context.Context, fingers []*abg.Finger) erro
 		return fmt.Errorf("conn: %w", err)
 	}
 
-	_, err := c.conn.abg.PerformMultiTouch(ctx, &abg.ActionMultiTouch{Finger: fingers})
+	_, err := c.conn.abg.PerformMultiTouch(ctx, &abg.ActionMultiTouch{Finger: fingers}, c.conn.callOptions()...)
 	if err != nil {
 		return fmt.Errorf("abg: %w", err)
 	}
@@ -56,7 +56,7 @@ func (c *ABG) PerformClick(ctx context.Context, action *abg.ActionClick) error {
 		return fmt.Errorf("conn: %w", err)
 	}
 
-	_, err := c.conn.abg.PerformClick(ctx, action)
+	_, err := c.conn.abg.PerformClick(ctx, action, c.conn.callOptions()...)
 	if err != nil {
 		return fmt.Errorf("abg: %w", err)
 	}
@@ -69,7 +69,7 @@ func (c *ABG) TypeText(ctx context.Context, text string) error {
 		return fmt.Errorf("conn: %w", err)
 	}
 
-	_, err := c.conn.abg.TypeText(ctx, &abg.ActionTypeText{Text: text})
+	_, err := c.conn.abg.TypeText(ctx, &abg.ActionTypeText{Text: text}, c.conn.callOptions()...)
 	if err != nil {
 		return fmt.Errorf("abg: %w", err)
 	}
@@ -82,7 +82,7 @@ func (c *ABG) PerformAction(ctx context.Context, key abg.ActionKey_Key) error {
 		return fmt.Errorf("conn: %w", err)
 	}
 
-	_, err := c.conn.abg.PerformAction(ctx, &abg.ActionKey{Key: key})
+	_, err := c.conn.abg.PerformAction(ctx, &abg.ActionKey{Key: key}, c.conn.callOptions()...)
 	if err != nil {
 		return fmt.Errorf("abg: %w", err)
 	}
@@ -6,6 +6,7 @@ import (
 
 	abg "github.com/merzzzl/accessibility-bridge-go"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
@@ -19,19 +20,29 @@ func WithABG(addr string) *OptionABG {
 	}
 }
 
-func (o *OptionABG) apply(ctx context.Context, conn *Conn) error {
+func (o *OptionABG) apply(_ context.Context, conn *Conn) error {
 	dial, err := grpc.NewClient(o.addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
 		return fmt.Errorf("init accessibility-bridge-go: %w", err)
 	}
 
-	go func() {
-		<-ctx.Done()
-
-		_ = dial.Close()
-	}()
-
+	conn.grpc = dial
 	conn.abg = abg.NewActionManagerClient(dial)
 
 	return nil
 }
+
+func (o *OptionABG) ready(ctx context.Context, conn *Conn) error {
+	conn.grpc.Connect()
+
+	for {
+		state := conn.grpc.GetState()
+		if state == connectivity.Ready {
+			return nil
+		}
+
+		if !conn.grpc.WaitForStateChange(ctx, state) {
+			return notReady("accessibility-bridge", fmt.Errorf("%s: %w", state, ctx.Err()))
+		}
+	}
+}
@@ -2,61 +2,181 @@ package device
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	abg "github.com/merzzzl/accessibility-bridge-go"
 	scrcpy "github.com/merzzzl/scrcpy-go"
 	"github.com/merzzzl/screen-flow/vision"
+	"google.golang.org/grpc"
 )
 
 type Option interface {
 	apply(ctx context.Context, conn *Conn) error
+	ready(ctx context.Context, conn *Conn) error
+}
+
+type OptionReadyTimeout struct {
+	timeout time.Duration
 }
 
 type Conn struct {
-	abg       abg.ActionManagerClient
-	scrcpy    *scrcpy.Client
-	decoder   *scrcpy.FFmpeg
-	clipboard chan string
-	vision    *vision.Pipe
+	abg          abg.ActionManagerClient
+	grpc         *grpc.ClientConn
+	scrcpy       *scrcpy.Client
+	decoder      *scrcpy.FFmpeg
+	clipboard    chan string
+	ackSeq       chan uint64
+	sequence     atomic.Uint64
+	vision       *vision.Pipe
+	streaming    chan struct{}
+	readyTimeout time.Duration
+	reconnect    *OptionReconnect
+	options      []Option
+	events       chan Event
+	log          *slog.Logger
+	frames       map[*frameHandler]struct{}
+	videoTaps    map[*videoTap]struct{}
+	videoHeader  []byte
+	mu           sync.RWMutex
+	up           chan struct{}
+	down         int
+	generation   uint64
+	cancel       context.CancelFunc
+	done         <-chan struct{}
+	wg           sync.WaitGroup
+	supervised   bool
+	closeOnce    sync.Once
+	closeErr     error
 }
 
 func Connect(ctx context.Context, options ...Option) (*Conn, error) {
 	ctx, cancel := context.WithCancel(ctx)
-	conn := &Conn{}
+	conn := &Conn{
+		clipboard:    make(chan string, 1),
+		ackSeq:       make(chan uint64, 1),
+		readyTimeout: 10 * time.Second,
+		options:      options,
+		events:       make(chan Event, 16),
+		log:          slog.New(discardHandler{}),
+		frames:       make(map[*frameHandler]struct{}),
+		videoTaps:    make(map[*videoTap]struct{}),
+		up:           make(chan struct{}),
+		cancel:       cancel,
+		done:         ctx.Done(),
+	}
+
+	close(conn.up)
+
+	for _, op := range options {
+		if l, ok := op.(*OptionLogger); ok {
+			_ = l.apply(ctx, conn)
+		}
+	}
 
 	for _, op := range options {
 		if err := op.apply(ctx, conn); err != nil {
-			cancel()
+			conn.log.Error("apply option", "err", err)
+			_ = conn.Close()
 
 			return nil, err
 		}
 	}
 
+	if conn.scrcpy != nil {
+		pl := conn.startPipeline(ctx)
+
+		conn.supervised = true
+		conn.wg.Add(1)
+
+		go conn.superviseSCRCPY(ctx, pl)
+	}
+
 	go func() {
-		if conn.scrcpy != nil {
-			_ = conn.scrcpy.Serve(ctx)
+		<-ctx.Done()
 
-			cancel()
-		}
+		_ = conn.Close()
 	}()
 
-	go func() {
-		if conn.vision != nil {
-			_ = conn.vision.Process(ctx)
+	readyCtx, cancelReady := context.WithTimeout(ctx, conn.readyTimeout)
+	defer cancelReady()
+
+	for _, op := range options {
+		if err := op.ready(readyCtx, conn); err != nil {
+			conn.log.Error("wait ready", "err", err)
+			_ = conn.Close()
 
-			cancel()
+			return nil, fmt.Errorf("wait ready: %w", err)
 		}
-	}()
+	}
 
-	t := time.NewTimer(time.Second)
+	if conn.grpc != nil && conn.reconnect != nil {
+		conn.wg.Add(1)
 
-	select {
-	case <-t.C:
-		return conn, nil
-	case <-ctx.Done():
-		return nil, ctx.Err()
+		go conn.superviseABG(ctx)
 	}
+
+	conn.log.Info("connected",
+		"abg", conn.abg != nil,
+		"scrcpy", conn.scrcpy != nil,
+		"vision", conn.vision != nil,
+	)
+
+	return conn, nil
+}
+
+func WithReadyTimeout(timeout time.Duration) *OptionReadyTimeout {
+	return &OptionReadyTimeout{
+		timeout: timeout,
+	}
+}
+
+func (o *OptionReadyTimeout) apply(_ context.Context, conn *Conn) error {
+	conn.readyTimeout = o.timeout
+
+	return nil
+}
+
+func (*OptionReadyTimeout) ready(context.Context, *Conn) error {
+	return nil
+}
+
+func (c *Conn) Close() error {
+	c.closeOnce.Do(func() {
+		c.cancel()
+
+		var errs []error
+
+		if dec := c.ffmpeg(); dec != nil && !c.supervised {
+			if err := dec.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("decoder: %w", err))
+			}
+		}
+
+		if c.grpc != nil {
+			if err := c.grpc.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("abg: %w", err))
+			}
+		}
+
+		c.wg.Wait()
+
+		c.closeErr = errors.Join(errs...)
+
+		if c.closeErr != nil {
+			c.log.Warn("close", "err", c.closeErr)
+		}
+	})
+
+	return c.closeErr
+}
+
+func (c *Conn) Done() <-chan struct{} {
+	return c.done
 }
 
 func (c *Conn) CheckABG() error {
@@ -68,7 +188,7 @@ func (c *Conn) CheckABG() error {
 }
 
 func (c *Conn) CheckSCRCPY() error {
-	if c != nil && c.scrcpy != nil {
+	if c != nil && c.client() != nil {
 		return nil
 	}
 
@@ -76,7 +196,7 @@ func (c *Conn) CheckSCRCPY() error {
 }
 
 func (c *Conn) CheckVision() error {
-	if c != nil && c.vision != nil {
+	if c != nil && c.pipe() != nil {
 		return nil
 	}
 
@@ -100,3 +220,24 @@ func (c *Conn) GetVision() *Vision {
 		conn: c,
 	}
 }
+
+func (c *Conn) client() *scrcpy.Client {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.scrcpy
+}
+
+func (c *Conn) ffmpeg() *scrcpy.FFmpeg {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.decoder
+}
+
+func (c *Conn) pipe() *vision.Pipe {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.vision
+}
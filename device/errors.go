@@ -1,9 +1,19 @@
 package device
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrNoABG    = errors.New("accessibility bridge not initialize")
 	ErrNoSCRCPY = errors.New("scrcpy not initialize")
 	ErrNoVision = errors.New("vision not initialize")
+	ErrNotReady = errors.New("backend not ready")
+	ErrClosed   = errors.New("connection closed")
+	ErrNoAck    = errors.New("no acknowledgement from device")
 )
+
+func notReady(backend string, err error) error {
+	return fmt.Errorf("%s: %w: %w", backend, ErrNotReady, err)
+}
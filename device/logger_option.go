@@ -0,0 +1,43 @@
+package device
+
+import (
+	"context"
+	"log/slog"
+)
+
+type OptionLogger struct {
+	logger *slog.Logger
+}
+
+type discardHandler struct{}
+
+func WithLogger(logger *slog.Logger) *OptionLogger {
+	return &OptionLogger{
+		logger: logger,
+	}
+}
+
+func (o *OptionLogger) apply(_ context.Context, conn *Conn) error {
+	if o.logger != nil {
+		conn.log = o.logger
+	}
+
+	return nil
+}
+
+func (*OptionLogger) ready(context.Context, *Conn) error {
+	return nil
+}
+
+func (c *Conn) Logger() *slog.Logger {
+	if c == nil || c.log == nil {
+		return slog.New(discardHandler{})
+	}
+
+	return c.log
+}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (d discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return d }
+func (d discardHandler) WithGroup(string) slog.Handler           { return d }
@@ -0,0 +1,217 @@
+package device
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+type Recorder struct {
+	path    string
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	chunks  chan []byte
+	done    chan struct{}
+	untap   func()
+	mu      sync.Mutex
+	synced  bool
+	closed  bool
+	startAt time.Time
+	err     error
+	once    sync.Once
+}
+
+type videoTap struct {
+	fn func(chunk, header []byte)
+}
+
+func (c *SCRCPY) Record(path string) (*Recorder, error) {
+	if err := c.conn.CheckSCRCPY(); err != nil {
+		return nil, fmt.Errorf("conn: %w", err)
+	}
+
+	cmd := exec.Command(
+		"ffmpeg",
+		"-loglevel", "quiet",
+		"-y",
+		"-use_wallclock_as_timestamps", "1",
+		"-f", "h264",
+		"-i", "pipe:0",
+		"-c", "copy",
+		path,
+	)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("open stdin pipe: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("start ffmpeg: %w", err)
+	}
+
+	r := &Recorder{
+		path:   path,
+		cmd:    cmd,
+		stdin:  stdin,
+		chunks: make(chan []byte, 256),
+		done:   make(chan struct{}),
+	}
+
+	go r.run()
+
+	r.untap = c.conn.tapVideo(r.write)
+
+	c.conn.log.Info("recording started", "path", path)
+
+	return r, nil
+}
+
+func (r *Recorder) Path() string {
+	return r.path
+}
+
+func (r *Recorder) StartAt() time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.startAt
+}
+
+func (r *Recorder) Close() error {
+	r.once.Do(func() {
+		r.untap()
+
+		r.mu.Lock()
+		r.closed = true
+		close(r.chunks)
+		r.mu.Unlock()
+
+		<-r.done
+
+		wait := make(chan error, 1)
+
+		go func() {
+			wait <- r.cmd.Wait()
+		}()
+
+		select {
+		case err := <-wait:
+			r.err = errors.Join(r.err, err)
+		case <-time.After(10 * time.Second):
+			_ = r.cmd.Process.Kill()
+			r.err = errors.Join(r.err, <-wait)
+		}
+
+		if r.err != nil {
+			r.err = fmt.Errorf("ffmpeg: %w", r.err)
+		}
+	})
+
+	return r.err
+}
+
+func (r *Recorder) run() {
+	defer close(r.done)
+
+	for chunk := range r.chunks {
+		if r.err != nil {
+			continue
+		}
+
+		if _, err := r.stdin.Write(chunk); err != nil {
+			r.err = err
+		}
+	}
+
+	if err := r.stdin.Close(); err != nil && r.err == nil {
+		r.err = err
+	}
+}
+
+func (r *Recorder) write(chunk, header []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return
+	}
+
+	if !r.synced {
+		if !hasKeyframe(chunk) {
+			return
+		}
+
+		if header != nil && !bytes.Equal(header, chunk) && !r.send(header) {
+			return
+		}
+
+		if r.startAt.IsZero() {
+			r.startAt = time.Now()
+		}
+
+		r.synced = true
+	}
+
+	if !r.send(chunk) {
+		r.synced = false
+	}
+}
+
+func (r *Recorder) send(chunk []byte) bool {
+	select {
+	case r.chunks <- bytes.Clone(chunk):
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *Conn) tapVideo(fn func(chunk, header []byte)) func() {
+	t := &videoTap{fn: fn}
+
+	c.mu.Lock()
+	c.videoTaps[t] = struct{}{}
+	c.mu.Unlock()
+
+	return func() {
+		c.mu.Lock()
+		delete(c.videoTaps, t)
+		c.mu.Unlock()
+	}
+}
+
+func (c *Conn) teeVideo(chunk []byte, first bool) {
+	c.mu.Lock()
+
+	if first {
+		c.videoHeader = bytes.Clone(chunk)
+	}
+
+	header := c.videoHeader
+	taps := make([]*videoTap, 0, len(c.videoTaps))
+
+	for t := range c.videoTaps {
+		taps = append(taps, t)
+	}
+
+	c.mu.Unlock()
+
+	for _, t := range taps {
+		t.fn(chunk, header)
+	}
+}
+
+func hasKeyframe(chunk []byte) bool {
+	for i := 0; i+3 < len(chunk); i++ {
+		if chunk[i] == 0 && chunk[i+1] == 0 && chunk[i+2] == 1 && chunk[i+3]&0x1f == 5 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -3,8 +3,11 @@ package device
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
+const ackTimeout = 5 * time.Second
+
 type SCRCPY struct {
 	conn *Conn
 }
@@ -14,7 +17,7 @@ func (c *SCRCPY) StartApp(name string) error {
 		return fmt.Errorf("conn: %w", err)
 	}
 
-	err := c.conn.scrcpy.StartApp(name)
+	err := c.conn.client().StartApp(name)
 	if err != nil {
 		return fmt.Errorf("scrcpy: %w", err)
 	}
@@ -27,7 +30,7 @@ func (c *SCRCPY) SetDisplayPower(on bool) error {
 		return fmt.Errorf("conn: %w", err)
 	}
 
-	if err := c.conn.scrcpy.SetDisplayPower(on); err != nil {
+	if err := c.conn.client().SetDisplayPower(on); err != nil {
 		return fmt.Errorf("scrcpy: %w", err)
 	}
 
@@ -39,7 +42,7 @@ func (c *SCRCPY) RotateDevice() error {
 		return fmt.Errorf("conn: %w", err)
 	}
 
-	if err := c.conn.scrcpy.RotateDevice(); err != nil {
+	if err := c.conn.client().RotateDevice(); err != nil {
 		return fmt.Errorf("scrcpy: %w", err)
 	}
 
@@ -51,7 +54,7 @@ func (c *SCRCPY) InjectText(text string) error {
 		return fmt.Errorf("conn: %w", err)
 	}
 
-	if err := c.conn.scrcpy.InjectText(text); err != nil {
+	if err := c.conn.client().InjectText(text); err != nil {
 		return fmt.Errorf("scrcpy: %w", err)
 	}
 
@@ -63,7 +66,11 @@ func (c *SCRCPY) GetClipboard(ctx context.Context, copyKey byte) (string, error)
 		return "", fmt.Errorf("conn: %w", err)
 	}
 
-	if err := c.conn.scrcpy.GetClipboard(copyKey); err != nil {
+	for len(c.conn.clipboard) > 0 {
+		<-c.conn.clipboard
+	}
+
+	if err := c.conn.client().GetClipboard(copyKey); err != nil {
 		return "", fmt.Errorf("scrcpy: %w", err)
 	}
 
@@ -80,19 +87,37 @@ func (c *SCRCPY) SetClipboard(sequence uint64, text string, paste bool) error {
 		return fmt.Errorf("conn: %w", err)
 	}
 
-	if err := c.conn.scrcpy.SetClipboard(sequence, text, paste); err != nil {
+	if err := c.conn.client().SetClipboard(sequence, text, paste); err != nil {
 		return fmt.Errorf("scrcpy: %w", err)
 	}
 
 	return nil
 }
 
+func (c *SCRCPY) SetClipboardWait(ctx context.Context, text string, paste bool) error {
+	if err := c.conn.CheckSCRCPY(); err != nil {
+		return fmt.Errorf("conn: %w", err)
+	}
+
+	sequence := c.conn.sequence.Add(1)
+
+	for len(c.conn.ackSeq) > 0 {
+		<-c.conn.ackSeq
+	}
+
+	if err := c.conn.client().SetClipboard(sequence, text, paste); err != nil {
+		return fmt.Errorf("scrcpy: %w", err)
+	}
+
+	return c.waitAck(ctx, sequence)
+}
+
 func (c *SCRCPY) UhidCreate(id, vendorID, productID uint16, name string, data []byte) error {
 	if err := c.conn.CheckSCRCPY(); err != nil {
 		return fmt.Errorf("conn: %w", err)
 	}
 
-	if err := c.conn.scrcpy.UhidCreate(id, vendorID, productID, name, data); err != nil {
+	if err := c.conn.client().UhidCreate(id, vendorID, productID, name, data); err != nil {
 		return fmt.Errorf("scrcpy: %w", err)
 	}
 
@@ -104,7 +129,7 @@ func (c *SCRCPY) UhidDestroy(id uint16) error {
 		return fmt.Errorf("conn: %w", err)
 	}
 
-	if err := c.conn.scrcpy.UhidDestroy(id); err != nil {
+	if err := c.conn.client().UhidDestroy(id); err != nil {
 		return fmt.Errorf("scrcpy: %w", err)
 	}
 
@@ -116,7 +141,7 @@ func (c *SCRCPY) UhidInput(id uint16, data []byte) error {
 		return fmt.Errorf("conn: %w", err)
 	}
 
-	if err := c.conn.scrcpy.UhidInput(id, data); err != nil {
+	if err := c.conn.client().UhidInput(id, data); err != nil {
 		return fmt.Errorf("scrcpy: %w", err)
 	}
 
@@ -128,7 +153,7 @@ func (c *SCRCPY) BackOrScreenOn(action byte) error {
 		return fmt.Errorf("conn: %w", err)
 	}
 
-	if err := c.conn.scrcpy.BackOrScreenOn(action); err != nil {
+	if err := c.conn.client().BackOrScreenOn(action); err != nil {
 		return fmt.Errorf("scrcpy: %w", err)
 	}
 
@@ -140,7 +165,7 @@ func (c *SCRCPY) CollapsePanels() error {
 		return fmt.Errorf("conn: %w", err)
 	}
 
-	if err := c.conn.scrcpy.CollapsePanels(); err != nil {
+	if err := c.conn.client().CollapsePanels(); err != nil {
 		return fmt.Errorf("scrcpy: %w", err)
 	}
 
@@ -152,7 +177,7 @@ func (c *SCRCPY) ExpandNotificationPanel() error {
 		return fmt.Errorf("conn: %w", err)
 	}
 
-	if err := c.conn.scrcpy.ExpandNotificationPanel(); err != nil {
+	if err := c.conn.client().ExpandNotificationPanel(); err != nil {
 		return fmt.Errorf("scrcpy: %w", err)
 	}
 
@@ -164,7 +189,7 @@ func (c *SCRCPY) ExpandSettingsPanel() error {
 		return fmt.Errorf("conn: %w", err)
 	}
 
-	if err := c.conn.scrcpy.ExpandSettingsPanel(); err != nil {
+	if err := c.conn.client().ExpandSettingsPanel(); err != nil {
 		return fmt.Errorf("scrcpy: %w", err)
 	}
 
@@ -176,7 +201,7 @@ func (c *SCRCPY) OpenHardKeyboardSettings() error {
 		return fmt.Errorf("conn: %w", err)
 	}
 
-	if err := c.conn.scrcpy.OpenHardKeyboardSettings(); err != nil {
+	if err := c.conn.client().OpenHardKeyboardSettings(); err != nil {
 		return fmt.Errorf("scrcpy: %w", err)
 	}
 
@@ -188,7 +213,7 @@ func (c *SCRCPY) InjectKeycode(keycode uint32, action byte, repeat, meta uint32)
 		return fmt.Errorf("conn: %w", err)
 	}
 
-	if err := c.conn.scrcpy.InjectKeycode(keycode, action, repeat, meta); err != nil {
+	if err := c.conn.client().InjectKeycode(keycode, action, repeat, meta); err != nil {
 		return fmt.Errorf("scrcpy: %w", err)
 	}
 
@@ -200,7 +225,7 @@ func (c *SCRCPY) InjectScroll(x, y int32, hscroll, vscroll int16, buttons uint32
 		return fmt.Errorf("conn: %w", err)
 	}
 
-	if err := c.conn.scrcpy.InjectScroll(x, y, hscroll, vscroll, buttons); err != nil {
+	if err := c.conn.client().InjectScroll(x, y, hscroll, vscroll, buttons); err != nil {
 		return fmt.Errorf("scrcpy: %w", err)
 	}
 
@@ -212,7 +237,7 @@ func (c *SCRCPY) InjectTouch(action byte, pointerID uint64, x, y uint32, pressur
 		return fmt.Errorf("conn: %w", err)
 	}
 
-	if err := c.conn.scrcpy.InjectTouch(action, pointerID, x, y, pressure, actionButton, buttons); err != nil {
+	if err := c.conn.client().InjectTouch(action, pointerID, x, y, pressure, actionButton, buttons); err != nil {
 		return fmt.Errorf("scrcpy: %w", err)
 	}
 
@@ -231,3 +256,21 @@ func (c *SCRCPY) waitClipboard(ctx context.Context) (string, error) {
 		return text, nil
 	}
 }
+
+func (c *SCRCPY) waitAck(ctx context.Context, sequence uint64) error {
+	timer := time.NewTimer(ackTimeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiter: %w", ctx.Err())
+		case <-timer.C:
+			return fmt.Errorf("clipboard sequence %d after %s: %w", sequence, ackTimeout, ErrNoAck)
+		case ack := <-c.conn.ackSeq:
+			if ack >= sequence {
+				return nil
+			}
+		}
+	}
+}
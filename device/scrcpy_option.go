@@ -2,7 +2,10 @@ package device
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
+	"io"
+	"sync"
 
 	scrcpy "github.com/merzzzl/scrcpy-go"
 )
@@ -11,6 +14,13 @@ type OptionSCRCPY struct {
 	addr string
 }
 
+type streamReader struct {
+	r       io.Reader
+	conn    *Conn
+	once    sync.Once
+	started chan struct{}
+}
+
 func WithSCRCPY(addr string) *OptionSCRCPY {
 	return &OptionSCRCPY{
 		addr: addr,
@@ -28,20 +38,34 @@ func (o *OptionSCRCPY) apply(ctx context.Context, conn *Conn) error {
 		return fmt.Errorf("init decoder: %w", err)
 	}
 
-	conn.scrcpy = client
-	conn.decoder = dec
-	conn.clipboard = make(chan string, 1)
+	streaming := make(chan struct{})
 
-	client.SetVideoHandler(dec.VideoHandler)
+	client.SetVideoHandler(func(r io.Reader) error {
+		return dec.VideoHandler(&streamReader{r: r, conn: conn, started: streaming})
+	})
 
 	client.SetControlHandler(func(_ context.Context, cm scrcpy.ControlMessage) error {
-		if cm.Type == 0 {
+		switch cm.Type {
+		case scrcpy.DeviceClipboard:
 			for len(conn.clipboard) > 0 {
 				<-conn.clipboard
 			}
 
 			select {
-			case conn.clipboard <- string(cm.Payload):
+			case conn.clipboard <- clipboardText(cm.Payload):
+			default:
+			}
+		case scrcpy.DeviceAckClipboard:
+			if len(cm.Payload) < 8 {
+				return nil
+			}
+
+			for len(conn.ackSeq) > 0 {
+				<-conn.ackSeq
+			}
+
+			select {
+			case conn.ackSeq <- binary.BigEndian.Uint64(cm.Payload[:8]):
 			default:
 			}
 		}
@@ -49,5 +73,49 @@ func (o *OptionSCRCPY) apply(ctx context.Context, conn *Conn) error {
 		return nil
 	})
 
+	conn.mu.Lock()
+	conn.scrcpy = client
+	conn.decoder = dec
+	conn.streaming = streaming
+	conn.mu.Unlock()
+
 	return nil
 }
+
+func (o *OptionSCRCPY) ready(ctx context.Context, conn *Conn) error {
+	conn.mu.RLock()
+	streaming := conn.streaming
+	conn.mu.RUnlock()
+
+	select {
+	case <-ctx.Done():
+		return notReady("scrcpy", ctx.Err())
+	case <-streaming:
+		return nil
+	}
+}
+
+func (s *streamReader) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	if n > 0 {
+		var first bool
+
+		s.once.Do(func() {
+			close(s.started)
+
+			first = true
+		})
+
+		s.conn.teeVideo(p[:n], first)
+	}
+
+	return n, err
+}
+
+func clipboardText(payload []byte) string {
+	if len(payload) >= 4 && int(binary.BigEndian.Uint32(payload[:4])) == len(payload)-4 {
+		return string(payload[4:])
+	}
+
+	return string(payload)
+}
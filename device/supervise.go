@@ -0,0 +1,390 @@
+package device
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	scrcpy "github.com/merzzzl/scrcpy-go"
+	"github.com/merzzzl/screen-flow/vision"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+type EventType int
+
+const (
+	EventDisconnected EventType = iota
+	EventReconnecting
+	EventReconnected
+	EventReconnectFailed
+)
+
+type Event struct {
+	Type    EventType
+	Backend string
+	Attempt int
+	Err     error
+	At      time.Time
+}
+
+type OptionReconnect struct {
+	attempts int
+	delay    time.Duration
+	maxDelay time.Duration
+}
+
+type pipeline struct {
+	cancel  context.CancelFunc
+	errs    chan error
+	workers int
+	decoder *scrcpy.FFmpeg
+	vision  *vision.Pipe
+}
+
+func WithReconnect(attempts int, delay, maxDelay time.Duration) *OptionReconnect {
+	return &OptionReconnect{
+		attempts: attempts,
+		delay:    delay,
+		maxDelay: maxDelay,
+	}
+}
+
+func (o *OptionReconnect) apply(_ context.Context, conn *Conn) error {
+	conn.reconnect = o
+
+	return nil
+}
+
+func (*OptionReconnect) ready(context.Context, *Conn) error {
+	return nil
+}
+
+func (o *OptionReconnect) backoff(attempt int) time.Duration {
+	delay := o.delay
+
+	for range attempt - 1 {
+		delay *= 2
+
+		if o.maxDelay > 0 && delay >= o.maxDelay {
+			return o.maxDelay
+		}
+	}
+
+	return delay
+}
+
+func (o *OptionReconnect) exhausted(attempt int) bool {
+	return o.attempts > 0 && attempt > o.attempts
+}
+
+func (t EventType) String() string {
+	switch t {
+	case EventDisconnected:
+		return "disconnected"
+	case EventReconnecting:
+		return "reconnecting"
+	case EventReconnected:
+		return "reconnected"
+	case EventReconnectFailed:
+		return "reconnect failed"
+	default:
+		return fmt.Sprintf("event %d", int(t))
+	}
+}
+
+func (t EventType) level() slog.Level {
+	switch t {
+	case EventDisconnected:
+		return slog.LevelWarn
+	case EventReconnectFailed:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+func (c *Conn) Events() <-chan Event {
+	return c.events
+}
+
+func (c *Conn) Generation() uint64 {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.generation
+}
+
+func (c *Conn) WaitReady(ctx context.Context) error {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.RLock()
+	up := c.up
+	c.mu.RUnlock()
+
+	select {
+	case <-up:
+		return nil
+	case <-c.done:
+		return fmt.Errorf("conn: %w", ErrClosed)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Conn) callOptions() []grpc.CallOption {
+	return []grpc.CallOption{grpc.WaitForReady(c.reconnect != nil)}
+}
+
+func (c *Conn) emit(event Event) {
+	event.At = time.Now()
+
+	c.log.Log(context.Background(), event.Type.level(), event.Type.String(),
+		"backend", event.Backend,
+		"attempt", event.Attempt,
+		"err", event.Err,
+	)
+
+	select {
+	case c.events <- event:
+	default:
+	}
+}
+
+func (c *Conn) markDown(backend string, err error) {
+	c.mu.Lock()
+
+	if c.down == 0 {
+		c.up = make(chan struct{})
+	}
+
+	c.down++
+	c.generation++
+	c.mu.Unlock()
+
+	c.emit(Event{Type: EventDisconnected, Backend: backend, Err: err})
+}
+
+func (c *Conn) markUp(backend string, attempt int) {
+	c.mu.Lock()
+
+	c.down--
+
+	if c.down == 0 {
+		close(c.up)
+	}
+
+	c.mu.Unlock()
+
+	c.emit(Event{Type: EventReconnected, Backend: backend, Attempt: attempt})
+}
+
+func (c *Conn) startPipeline(ctx context.Context) *pipeline {
+	ctx, cancel := context.WithCancel(ctx)
+
+	c.mu.RLock()
+	client, dec, pipe := c.scrcpy, c.decoder, c.vision
+	c.mu.RUnlock()
+
+	pl := &pipeline{
+		cancel:  cancel,
+		errs:    make(chan error, 2),
+		workers: 1,
+		decoder: dec,
+		vision:  pipe,
+	}
+
+	go func() {
+		pl.errs <- client.Serve(ctx)
+	}()
+
+	if pipe != nil {
+		pl.workers++
+
+		go func() {
+			pl.errs <- pipe.Process(ctx)
+		}()
+	}
+
+	return pl
+}
+
+func (pl *pipeline) wait() error {
+	err := <-pl.errs
+	pl.workers--
+
+	return err
+}
+
+func (pl *pipeline) close() {
+	pl.cancel()
+
+	_ = pl.decoder.Close()
+
+	for range pl.workers {
+		<-pl.errs
+	}
+
+	if pl.vision != nil {
+		pl.vision.Close()
+	}
+}
+
+func (c *Conn) superviseSCRCPY(ctx context.Context, pl *pipeline) {
+	defer c.wg.Done()
+
+	for {
+		err := pl.wait()
+
+		if ctx.Err() != nil || c.reconnect == nil {
+			if ctx.Err() == nil {
+				c.log.Error("scrcpy pipeline stopped", "err", err)
+			}
+
+			pl.close()
+			c.cancel()
+
+			return
+		}
+
+		c.markDown("scrcpy", err)
+		pl.close()
+
+		var attempt int
+
+		pl, attempt, err = c.redialSCRCPY(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				c.emit(Event{Type: EventReconnectFailed, Backend: "scrcpy", Attempt: attempt, Err: err})
+			}
+
+			c.cancel()
+
+			return
+		}
+
+		c.markUp("scrcpy", attempt)
+	}
+}
+
+func (c *Conn) redialSCRCPY(ctx context.Context) (*pipeline, int, error) {
+	var lastErr error
+
+	for attempt := 1; !c.reconnect.exhausted(attempt); attempt++ {
+		t := time.NewTimer(c.reconnect.backoff(attempt))
+
+		select {
+		case <-ctx.Done():
+			t.Stop()
+
+			return nil, attempt, ctx.Err()
+		case <-t.C:
+		}
+
+		c.emit(Event{Type: EventReconnecting, Backend: "scrcpy", Attempt: attempt})
+
+		pl, err := c.dialPipeline(ctx)
+		if err == nil {
+			return pl, attempt, nil
+		}
+
+		lastErr = err
+	}
+
+	return nil, c.reconnect.attempts, fmt.Errorf("reconnect: %w", lastErr)
+}
+
+func (c *Conn) dialPipeline(ctx context.Context) (*pipeline, error) {
+	ops := make([]Option, 0, 2)
+
+	for _, op := range c.options {
+		switch op.(type) {
+		case *OptionSCRCPY, *OptionVision:
+			ops = append(ops, op)
+		}
+	}
+
+	for _, op := range ops {
+		if err := op.apply(ctx, c); err != nil {
+			if dec := c.ffmpeg(); dec != nil {
+				_ = dec.Close()
+			}
+
+			return nil, err
+		}
+	}
+
+	pl := c.startPipeline(ctx)
+
+	readyCtx, cancel := context.WithTimeout(ctx, c.readyTimeout)
+	defer cancel()
+
+	for _, op := range ops {
+		if err := op.ready(readyCtx, c); err != nil {
+			pl.close()
+
+			return nil, err
+		}
+	}
+
+	return pl, nil
+}
+
+func (c *Conn) superviseABG(ctx context.Context) {
+	defer c.wg.Done()
+
+	var (
+		attempt int
+		down    bool
+		state   = c.grpc.GetState()
+	)
+
+	for c.grpc.WaitForStateChange(ctx, state) {
+		state = c.grpc.GetState()
+
+		switch state {
+		case connectivity.Ready:
+			if down {
+				down = false
+				c.markUp("accessibility-bridge", attempt)
+			}
+
+			attempt = 0
+		case connectivity.Shutdown:
+			return
+		case connectivity.TransientFailure:
+			if !down {
+				down = true
+				c.markDown("accessibility-bridge", fmt.Errorf("grpc %s: %w", state, ErrNotReady))
+			}
+
+			c.grpc.Connect()
+		case connectivity.Idle:
+			if down {
+				c.grpc.Connect()
+			}
+		case connectivity.Connecting:
+			if !down {
+				continue
+			}
+
+			attempt++
+
+			if c.reconnect.exhausted(attempt) {
+				c.emit(Event{Type: EventReconnectFailed, Backend: "accessibility-bridge", Attempt: attempt - 1})
+				c.cancel()
+
+				return
+			}
+
+			c.emit(Event{Type: EventReconnecting, Backend: "accessibility-bridge", Attempt: attempt})
+		}
+	}
+}
@@ -4,21 +4,153 @@ import (
 	"context"
 	"fmt"
 	"image"
+	"time"
+
+	"github.com/merzzzl/screen-flow/vision"
 )
 
 type Vision struct {
 	conn *Conn
 }
 
+type frameHandler struct {
+	fn func(vision.Frame)
+}
+
 func (c *Vision) Find(ctx context.Context, img image.Image) (image.Point, error) {
 	if err := c.conn.CheckVision(); err != nil {
 		return image.Pt(0, 0), fmt.Errorf("conn: %w", err)
 	}
 
-	out, err := c.conn.vision.Find(ctx, img)
+	out, err := c.conn.pipe().Find(ctx, img)
+	if err != nil {
+		return image.Pt(0, 0), fmt.Errorf("vision: %w", err)
+	}
+
+	return out, nil
+}
+
+func (c *Vision) FindMatch(ctx context.Context, img image.Image) (*vision.Match, error) {
+	if err := c.conn.CheckVision(); err != nil {
+		return nil, fmt.Errorf("conn: %w", err)
+	}
+
+	out, err := c.conn.pipe().FindMatch(ctx, img)
+	if err != nil {
+		return nil, fmt.Errorf("vision: %w", err)
+	}
+
+	return out, nil
+}
+
+func (c *Vision) FindAny(ctx context.Context, imgs ...image.Image) (int, *vision.Match, error) {
+	if err := c.conn.CheckVision(); err != nil {
+		return -1, nil, fmt.Errorf("conn: %w", err)
+	}
+
+	index, out, err := c.conn.pipe().FindAny(ctx, imgs...)
+	if err != nil {
+		return -1, nil, fmt.Errorf("vision: %w", err)
+	}
+
+	return index, out, nil
+}
+
+func (c *Vision) FindAll(ctx context.Context, img image.Image, threshold float64) ([]*vision.Match, error) {
+	if err := c.conn.CheckVision(); err != nil {
+		return nil, fmt.Errorf("conn: %w", err)
+	}
+
+	out, err := c.conn.pipe().FindAll(ctx, img, threshold)
 	if err != nil {
-		return image.Pt(0, 0), fmt.Errorf("abg: %w", err)
+		return nil, fmt.Errorf("vision: %w", err)
 	}
 
 	return out, nil
 }
+
+func (c *Vision) WaitGone(ctx context.Context, img image.Image, area *image.Rectangle, frames int) error {
+	if err := c.conn.CheckVision(); err != nil {
+		return fmt.Errorf("conn: %w", err)
+	}
+
+	if err := c.conn.pipe().WaitGone(ctx, img, area, frames); err != nil {
+		return fmt.Errorf("vision: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Vision) WaitStable(ctx context.Context, threshold float64, minDur time.Duration) error {
+	if err := c.conn.CheckVision(); err != nil {
+		return fmt.Errorf("conn: %w", err)
+	}
+
+	if err := c.conn.pipe().WaitStable(ctx, threshold, minDur); err != nil {
+		return fmt.Errorf("vision: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Vision) Screenshot(ctx context.Context) (image.Image, error) {
+	if err := c.conn.CheckVision(); err != nil {
+		return nil, fmt.Errorf("conn: %w", err)
+	}
+
+	img, err := c.conn.pipe().Screenshot(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("vision: %w", err)
+	}
+
+	return img, nil
+}
+
+func (c *Vision) LatestFrame() (image.Image, time.Time, error) {
+	if err := c.conn.CheckVision(); err != nil {
+		return nil, time.Time{}, fmt.Errorf("conn: %w", err)
+	}
+
+	img, at, err := c.conn.pipe().Latest()
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("vision: %w", err)
+	}
+
+	return img, at, nil
+}
+
+func (c *Vision) Subscribe(opts vision.SubscribeOptions) (*vision.Subscription, error) {
+	if err := c.conn.CheckVision(); err != nil {
+		return nil, fmt.Errorf("conn: %w", err)
+	}
+
+	return c.conn.pipe().Subscribe(opts), nil
+}
+
+func (c *Vision) OnFrame(fn func(vision.Frame)) func() {
+	h := &frameHandler{fn: fn}
+
+	c.conn.mu.Lock()
+	c.conn.frames[h] = struct{}{}
+	c.conn.mu.Unlock()
+
+	return func() {
+		c.conn.mu.Lock()
+		delete(c.conn.frames, h)
+		c.conn.mu.Unlock()
+	}
+}
+
+func (c *Conn) dispatchFrame(frame vision.Frame) {
+	c.mu.RLock()
+	handlers := make([]*frameHandler, 0, len(c.frames))
+
+	for h := range c.frames {
+		handlers = append(handlers, h)
+	}
+	c.mu.RUnlock()
+
+	for _, h := range handlers {
+		h.fn(frame)
+	}
+}
@@ -18,18 +18,37 @@ func WithVision(algo vision.Algorithm) *OptionVision {
 }
 
 func (o *OptionVision) apply(_ context.Context, conn *Conn) error {
-	handshake := conn.scrcpy.GetHandshake()
+	if err := conn.CheckSCRCPY(); err != nil {
+		return fmt.Errorf("need scrcpy: %w", err)
+	}
+
+	handshake := conn.client().GetHandshake()
 
 	if handshake.Height == 0 || handshake.Width == 0 {
 		return fmt.Errorf("need scrcpy: %w", ErrNoSCRCPY)
 	}
 
-	conn.vision = vision.NewPipe(
-		conn.decoder,
+	pipe := vision.NewPipe(
+		conn.ffmpeg(),
 		int(handshake.Width),
 		int(handshake.Height),
 		o.algo,
-	)
+	).WithLogger(conn.Logger().With("backend", "vision"))
+
+	pipe.OnFrame(conn.dispatchFrame)
+
+	conn.mu.Lock()
+	conn.vision = pipe
+	conn.mu.Unlock()
 
 	return nil
 }
+
+func (o *OptionVision) ready(ctx context.Context, conn *Conn) error {
+	select {
+	case <-ctx.Done():
+		return notReady("vision", ctx.Err())
+	case <-conn.pipe().Ready():
+		return nil
+	}
+}
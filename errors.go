@@ -0,0 +1,48 @@
+package screenflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrMaxIterations = errors.New("max iterations reached")
+
+type TimeoutError struct {
+	Index   int
+	Path    string
+	Kind    string
+	Timeout time.Duration
+	Flow    bool
+	Err     error
+}
+
+type flowDeadline struct {
+	timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	step := e.Path
+	if step == "" {
+		step = fmt.Sprintf("step %d", e.Index)
+	}
+
+	if e.Flow {
+		return fmt.Sprintf("flow deadline %s exceeded during %s (%s)", e.Timeout, step, e.Kind)
+	}
+
+	return fmt.Sprintf("%s (%s) timed out after %s", step, e.Kind, e.Timeout)
+}
+
+func (e *TimeoutError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{context.DeadlineExceeded}
+	}
+
+	return []error{context.DeadlineExceeded, e.Err}
+}
+
+func (e *flowDeadline) Error() string {
+	return fmt.Sprintf("flow deadline %s exceeded", e.timeout)
+}
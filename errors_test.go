@@ -0,0 +1,57 @@
+package screenflow
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimeoutError(t *testing.T) {
+	errStep := errors.New("step failed")
+
+	tests := []struct {
+		name   string
+		err    *TimeoutError
+		want   string
+		unwrap []error
+	}{
+		{
+			name:   "step timeout by index",
+			err:    &TimeoutError{Index: 2, Kind: "TapXY", Timeout: time.Second},
+			want:   "step 2 (TapXY) timed out after 1s",
+			unwrap: []error{context.DeadlineExceeded},
+		},
+		{
+			name:   "step timeout by path",
+			err:    &TimeoutError{Index: 0, Path: "step 1 > then step 0", Kind: "WaitElement", Timeout: 5 * time.Second, Err: errStep},
+			want:   "step 1 > then step 0 (WaitElement) timed out after 5s",
+			unwrap: []error{context.DeadlineExceeded, errStep},
+		},
+		{
+			name:   "flow deadline",
+			err:    &TimeoutError{Index: 3, Kind: "Swipe", Timeout: time.Minute, Flow: true},
+			want:   "flow deadline 1m0s exceeded during step 3 (Swipe)",
+			unwrap: []error{context.DeadlineExceeded},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+
+			for _, target := range tt.unwrap {
+				if !errors.Is(tt.err, target) {
+					t.Errorf("errors.Is(%v) = false, want true", target)
+				}
+			}
+
+			var timeout *TimeoutError
+			if !errors.As(error(tt.err), &timeout) || timeout != tt.err {
+				t.Errorf("errors.As() did not return the TimeoutError")
+			}
+		})
+	}
+}
@@ -2,8 +2,11 @@ package screenflow
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"image"
+	"log/slog"
+	"reflect"
 	"time"
 
 	"github.com/merzzzl/screen-flow/actions"
@@ -11,20 +14,41 @@ import (
 )
 
 type Flow struct {
-	steps []FlowStep
+	name      string
+	steps     []FlowStep
+	retry     *RetryPolicy
+	timeout   time.Duration
+	pacing    time.Duration
+	vars      map[string]any
+	observers []Observer
+	log       *slog.Logger
+	artifacts string
+	recording string
+	frames    bool
 }
 
 type FlowState struct {
+	Name           string
 	StartAt        time.Time
 	EndAt          time.Time
 	StepsCount     int
 	CompletedSteps int
+	Steps          []*StepResult
+	Vars           *actions.Vars
+	SubFlows       []*FlowState
+	Video          string
+	Chapters       string
+	Err            error
 }
 
 type FlowStep interface {
 	Handle(ctx context.Context, conn *device.Conn) error
 }
 
+type stateKey struct{}
+
+type flowKey struct{}
+
 type customAction struct {
 	handler func(ctx context.Context, conn *device.Conn) error
 }
@@ -35,6 +59,12 @@ func NewFlow() *Flow {
 	}
 }
 
+func (f *Flow) WithName(name string) *Flow {
+	f.name = name
+
+	return f
+}
+
 func (f *Flow) Load(steps []FlowStep) *Flow {
 	f.steps = append(f.steps, steps...)
 
@@ -42,29 +72,145 @@ func (f *Flow) Load(steps []FlowStep) *Flow {
 }
 
 func (f *Flow) Run(ctx context.Context, options ...device.Option) (*FlowState, error) {
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
-
 	conn, err := device.Connect(ctx, options...)
 	if err != nil {
 		return nil, fmt.Errorf("connect to device: %w", err)
 	}
 
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	return f.RunOn(ctx, conn)
+}
+
+func (f *Flow) Handle(ctx context.Context, conn *device.Conn) error {
+	if _, err := f.RunOn(ctx, conn); err != nil {
+		return fmt.Errorf("%s > %w", f.kind(), err)
+	}
+
+	return nil
+}
+
+func (f *Flow) WithLogger(logger *slog.Logger) *Flow {
+	f.log = logger
+
+	return f
+}
+
+func (f *Flow) logger(ctx context.Context, conn *device.Conn) (context.Context, *slog.Logger) {
+	log := f.log
+
+	if log == nil {
+		log = actions.LoggerFromContext(ctx, conn)
+	}
+
+	log = log.With("flow", f.kind())
+
+	return actions.WithLogger(ctx, log), log
+}
+
+func (*Flow) composite() {}
+
+func (f *Flow) kind() string {
+	if f.name == "" {
+		return "Flow"
+	}
+
+	return f.name
+}
+
+func (f *Flow) RunOn(ctx context.Context, conn *device.Conn) (_ *FlowState, err error) {
+	ctx, vars, restoreVars := f.bindVars(ctx)
+	defer restoreVars()
+
 	state := &FlowState{
+		Name:       f.name,
 		StartAt:    time.Now(),
 		StepsCount: len(f.steps),
+		Vars:       vars,
+	}
+
+	if parent, ok := ctx.Value(stateKey{}).(*FlowState); ok {
+		parent.SubFlows = append(parent.SubFlows, state)
+	}
+
+	ctx = context.WithValue(ctx, stateKey{}, state)
+	ctx = context.WithValue(ctx, flowKey{}, f)
+
+	if f.retry != nil {
+		ctx = context.WithValue(ctx, retryKey{}, f.retry)
+	}
+
+	if path, ok := ctx.Value(pathKey{}).(string); ok {
+		ctx = context.WithValue(ctx, pathKey{}, path+" > "+f.kind())
 	}
 
+	ctx, obs, cancelFrames := f.observe(ctx, conn)
+	defer cancelFrames()
+
+	ctx, log := f.logger(ctx, conn)
+	ctx = f.bindArtifacts(ctx, conn)
+
+	log.Info("flow started", "steps", len(f.steps))
+	obs.OnFlowStart(state)
+
 	defer func() {
 		state.EndAt = time.Now()
+		state.Err = err
+
+		if err != nil {
+			log.Error("flow failed", "completed", state.CompletedSteps, "took", state.EndAt.Sub(state.StartAt), "err", err)
+		} else {
+			log.Info("flow finished", "completed", state.CompletedSteps, "took", state.EndAt.Sub(state.StartAt))
+		}
+
+		obs.OnFlowEnd(state, err)
+	}()
+
+	stopRecording, err := f.startRecording(conn, state)
+	if err != nil {
+		return state, err
+	}
+
+	defer func() {
+		if stopErr := stopRecording(); stopErr != nil {
+			log.Warn("recording", "err", stopErr)
+		}
 	}()
 
+	runCtx := ctx
+
+	if f.timeout > 0 {
+		var cancelRun context.CancelFunc
+
+		runCtx, cancelRun = context.WithTimeoutCause(ctx, f.timeout, &flowDeadline{timeout: f.timeout})
+		defer cancelRun()
+	}
+
 	for i, step := range f.steps {
 		if ctx.Err() != nil {
 			return state, ctx.Err()
 		}
 
-		if err := step.Handle(ctx, conn); err != nil {
+		if runCtx.Err() != nil {
+			return state, fmt.Errorf("step %d failed: %w", i, deadlineError(runCtx, i, "", stepKind(step), runCtx.Err()))
+		}
+
+		if i > 0 {
+			if err := sleep(runCtx, f.pacing); err != nil {
+				return state, fmt.Errorf("step %d failed: %w", i, deadlineError(runCtx, i, "", stepKind(step), err))
+			}
+		}
+
+		stepCtx, res := state.beginStep(runCtx, i, "", step)
+
+		attempts, err := f.handle(stepCtx, conn, step)
+		err = res.deadline(stepCtx, err)
+
+		res.end(attempts, err)
+
+		if err != nil {
 			return state, fmt.Errorf("step %d failed: %w", i, err)
 		}
 
@@ -74,6 +220,91 @@ func (f *Flow) Run(ctx context.Context, options ...device.Option) (*FlowState, e
 	return state, nil
 }
 
+func deadlineError(ctx context.Context, index int, path, kind string, err error) error {
+	var (
+		te *TimeoutError
+		fd *flowDeadline
+	)
+
+	if err == nil || errors.As(err, &te) || !errors.As(context.Cause(ctx), &fd) {
+		return err
+	}
+
+	return &TimeoutError{
+		Index:   index,
+		Path:    path,
+		Kind:    kind,
+		Timeout: fd.timeout,
+		Flow:    true,
+		Err:     err,
+	}
+}
+
+func runSteps(ctx context.Context, conn *device.Conn, name string, steps []FlowStep) error {
+	state, _ := ctx.Value(stateKey{}).(*FlowState)
+	owner, _ := ctx.Value(flowKey{}).(*Flow)
+
+	if owner == nil {
+		owner = NewFlow()
+	}
+
+	if state != nil {
+		state.StepsCount += len(steps)
+	}
+
+	for i, step := range steps {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if i > 0 {
+			if err := sleep(ctx, owner.pacing); err != nil {
+				return fmt.Errorf("%s step %d failed: %w", name, i, err)
+			}
+		}
+
+		stepCtx, res := state.beginStep(ctx, i, name, step)
+
+		attempts, err := owner.handle(stepCtx, conn, step)
+		err = res.deadline(stepCtx, err)
+
+		res.end(attempts, err)
+
+		if err != nil {
+			return fmt.Errorf("%s step %d failed: %w", name, i, err)
+		}
+
+		if state != nil {
+			state.CompletedSteps++
+		}
+	}
+
+	return nil
+}
+
+func stepKind(step FlowStep) string {
+	for {
+		w, ok := step.(interface{ unwrap() FlowStep })
+		if !ok {
+			break
+		}
+
+		step = w.unwrap()
+	}
+
+	if k, ok := step.(interface{ kind() string }); ok {
+		return k.kind()
+	}
+
+	t := reflect.TypeOf(step)
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t.Name()
+}
+
 func (a *customAction) Handle(ctx context.Context, conn *device.Conn) error {
 	err := a.handler(ctx, conn)
 	if err != nil {
@@ -83,6 +314,10 @@ func (a *customAction) Handle(ctx context.Context, conn *device.Conn) error {
 	return nil
 }
 
+func (*customAction) kind() string {
+	return "ActionFunc"
+}
+
 func ActionTap(x, y int) FlowStep {
 	return &actions.ActionTap{
 		X: x,
@@ -96,6 +331,18 @@ func (f *Flow) ActionTap(x, y int) *Flow {
 	return f
 }
 
+func ActionTapVar(name string) FlowStep {
+	return &actions.ActionTap{
+		Var: name,
+	}
+}
+
+func (f *Flow) ActionTapVar(name string) *Flow {
+	f.steps = append(f.steps, ActionTapVar(name))
+
+	return f
+}
+
 func ActionSwipe(x1, y1, x2, y2 int) FlowStep {
 	return &actions.ActionSwipe{
 		X1: x1,
@@ -149,6 +396,35 @@ func (f *Flow) ActionTapImage(img image.Image, area *image.Rectangle, dur time.D
 	return f
 }
 
+func ActionTapAnyImage(area *image.Rectangle, dur time.Duration, imgs ...image.Image) FlowStep {
+	return &actions.ActionTapAnyImage{
+		ImageTemplates: imgs,
+		Duration:       dur,
+		SearchArea:     area,
+	}
+}
+
+func (f *Flow) ActionTapAnyImage(area *image.Rectangle, dur time.Duration, imgs ...image.Image) *Flow {
+	f.steps = append(f.steps, ActionTapAnyImage(area, dur, imgs...))
+
+	return f
+}
+
+func ActionTapNthImage(img image.Image, n int, area *image.Rectangle, dur time.Duration) FlowStep {
+	return &actions.ActionTapNthImage{
+		ImageTemplate: img,
+		Index:         n,
+		Duration:      dur,
+		SearchArea:    area,
+	}
+}
+
+func (f *Flow) ActionTapNthImage(img image.Image, n int, area *image.Rectangle, dur time.Duration) *Flow {
+	f.steps = append(f.steps, ActionTapNthImage(img, n, area, dur))
+
+	return f
+}
+
 func ActionTapElement(regexp, uniqid string, dur time.Duration) FlowStep {
 	return &actions.ActionTapElement{
 		Regexp:   regexp,
@@ -195,6 +471,43 @@ func (f *Flow) ActionSwipeElement(regexp, uniqid string, h, w int, dur time.Dura
 	return f
 }
 
+func ActionSetClipboard(text string, paste bool) FlowStep {
+	return &actions.ActionSetClipboard{
+		Text:  text,
+		Paste: paste,
+	}
+}
+
+func (f *Flow) ActionSetClipboard(text string, paste bool) *Flow {
+	f.steps = append(f.steps, ActionSetClipboard(text, paste))
+
+	return f
+}
+
+func ActionGetClipboard(name string) FlowStep {
+	return &actions.ActionGetClipboard{
+		Var: name,
+	}
+}
+
+func (f *Flow) ActionGetClipboard(name string) *Flow {
+	f.steps = append(f.steps, ActionGetClipboard(name))
+
+	return f
+}
+
+func ActionAssertClipboard(regexp string) FlowStep {
+	return &actions.ActionAssertClipboard{
+		Regexp: regexp,
+	}
+}
+
+func (f *Flow) ActionAssertClipboard(regexp string) *Flow {
+	f.steps = append(f.steps, ActionAssertClipboard(regexp))
+
+	return f
+}
+
 func ActionFunc(fn func(ctx context.Context, conn *device.Conn) error) FlowStep {
 	return &customAction{handler: fn}
 }
@@ -219,6 +532,50 @@ func (f *Flow) ActionWaitImage(img image.Image, area *image.Rectangle, dur *time
 	return f
 }
 
+func ActionFindImage(name string, img image.Image, area *image.Rectangle, dur *time.Duration) FlowStep {
+	return &actions.ActionWaitImage{
+		ImageTemplate: img,
+		SearchArea:    area,
+		Duration:      dur,
+		Var:           name,
+	}
+}
+
+func (f *Flow) ActionFindImage(name string, img image.Image, area *image.Rectangle, dur *time.Duration) *Flow {
+	f.steps = append(f.steps, ActionFindImage(name, img, area, dur))
+
+	return f
+}
+
+func ActionWaitImageGone(img image.Image, area *image.Rectangle, frames int, dur *time.Duration) FlowStep {
+	return &actions.ActionWaitImageGone{
+		ImageTemplate: img,
+		SearchArea:    area,
+		Frames:        frames,
+		Duration:      dur,
+	}
+}
+
+func (f *Flow) ActionWaitImageGone(img image.Image, area *image.Rectangle, frames int, dur *time.Duration) *Flow {
+	f.steps = append(f.steps, ActionWaitImageGone(img, area, frames, dur))
+
+	return f
+}
+
+func ActionWaitStatic(threshold float64, minDur time.Duration, dur *time.Duration) FlowStep {
+	return &actions.ActionWaitStatic{
+		Threshold:   threshold,
+		MinDuration: minDur,
+		Duration:    dur,
+	}
+}
+
+func (f *Flow) ActionWaitStatic(threshold float64, minDur time.Duration, dur *time.Duration) *Flow {
+	f.steps = append(f.steps, ActionWaitStatic(threshold, minDur, dur))
+
+	return f
+}
+
 func ActionWaitElement(regexp, uniqid string, dur *time.Duration) FlowStep {
 	return &actions.ActionWaitElement{
 		Regexp:   regexp,
@@ -233,6 +590,34 @@ func (f *Flow) ActionWaitElement(regexp, uniqid string, dur *time.Duration) *Flo
 	return f
 }
 
+func ActionFindElement(name, regexp, uniqid string, dur *time.Duration) FlowStep {
+	return &actions.ActionWaitElement{
+		Regexp:   regexp,
+		UniqueID: uniqid,
+		Duration: dur,
+		Var:      name,
+	}
+}
+
+func (f *Flow) ActionFindElement(name, regexp, uniqid string, dur *time.Duration) *Flow {
+	f.steps = append(f.steps, ActionFindElement(name, regexp, uniqid, dur))
+
+	return f
+}
+
+func ActionCaptureFrame(path, name string) FlowStep {
+	return &actions.ActionCaptureFrame{
+		Path: path,
+		Var:  name,
+	}
+}
+
+func (f *Flow) ActionCaptureFrame(path, name string) *Flow {
+	f.steps = append(f.steps, ActionCaptureFrame(path, name))
+
+	return f
+}
+
 func ActionWait(dur time.Duration) FlowStep {
 	return &actions.ActionWait{
 		Duration: dur,
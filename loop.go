@@ -0,0 +1,127 @@
+package screenflow
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/merzzzl/screen-flow/device"
+)
+
+type repeatAction struct {
+	count int
+	steps []FlowStep
+}
+
+type whileAction struct {
+	cond    Condition
+	steps   []FlowStep
+	maxIter int
+}
+
+type forEachAction[T any] struct {
+	values []T
+	steps  func(v T) []FlowStep
+}
+
+func (a *repeatAction) Handle(ctx context.Context, conn *device.Conn) error {
+	for i := range a.count {
+		if err := runSteps(ctx, conn, fmt.Sprintf("iteration %d", i), a.steps); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (*repeatAction) kind() string {
+	return "Repeat"
+}
+
+func (*repeatAction) composite() {}
+
+func (a *whileAction) Handle(ctx context.Context, conn *device.Conn) error {
+	for i := 0; ; i++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		ok, err := a.cond.Check(ctx, conn)
+		if err != nil {
+			return fmt.Errorf("check condition: %w", err)
+		}
+
+		if !ok {
+			return nil
+		}
+
+		if a.maxIter > 0 && i >= a.maxIter {
+			return fmt.Errorf("while after %d iterations: %w", i, ErrMaxIterations)
+		}
+
+		if err := runSteps(ctx, conn, fmt.Sprintf("iteration %d", i), a.steps); err != nil {
+			return err
+		}
+	}
+}
+
+func (*whileAction) kind() string {
+	return "While"
+}
+
+func (*whileAction) composite() {}
+
+func (a *forEachAction[T]) Handle(ctx context.Context, conn *device.Conn) error {
+	for i, v := range a.values {
+		if err := runSteps(ctx, conn, fmt.Sprintf("iteration %d", i), a.steps(v)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (*forEachAction[T]) kind() string {
+	return "ForEach"
+}
+
+func (*forEachAction[T]) composite() {}
+
+func Repeat(count int, steps []FlowStep) FlowStep {
+	return &repeatAction{
+		count: count,
+		steps: steps,
+	}
+}
+
+func (f *Flow) Repeat(count int, steps []FlowStep) *Flow {
+	f.steps = append(f.steps, Repeat(count, steps))
+
+	return f
+}
+
+func While(cond Condition, steps []FlowStep, maxIter int) FlowStep {
+	return &whileAction{
+		cond:    cond,
+		steps:   steps,
+		maxIter: maxIter,
+	}
+}
+
+func (f *Flow) While(cond Condition, steps []FlowStep, maxIter int) *Flow {
+	f.steps = append(f.steps, While(cond, steps, maxIter))
+
+	return f
+}
+
+func ForEach[T any](values []T, steps func(v T) []FlowStep) FlowStep {
+	return &forEachAction[T]{
+		values: values,
+		steps:  steps,
+	}
+}
+
+func (f *Flow) ForEach(values []any, steps func(v any) []FlowStep) *Flow {
+	f.steps = append(f.steps, ForEach(values, steps))
+
+	return f
+}
@@ -0,0 +1,91 @@
+package screenflow
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/merzzzl/screen-flow/device"
+)
+
+func noop() FlowStep {
+	return ActionFunc(func(context.Context, *device.Conn) error { return nil })
+}
+
+func countdown(n int) Condition {
+	return Predicate(func(context.Context, *device.Conn) bool {
+		n--
+
+		return n >= 0
+	})
+}
+
+func TestFlowStateCounters(t *testing.T) {
+	tests := []struct {
+		name      string
+		steps     []FlowStep
+		total     int
+		completed int
+		err       error
+	}{
+		{
+			name:      "if then",
+			steps:     []FlowStep{If(countdown(1), []FlowStep{noop()}, []FlowStep{noop(), noop()})},
+			total:     2,
+			completed: 2,
+		},
+		{
+			name:      "if else",
+			steps:     []FlowStep{If(countdown(0), []FlowStep{noop()}, []FlowStep{noop(), noop()})},
+			total:     3,
+			completed: 3,
+		},
+		{
+			name:      "repeat",
+			steps:     []FlowStep{noop(), Repeat(3, []FlowStep{noop(), noop()})},
+			total:     8,
+			completed: 8,
+		},
+		{
+			name:      "while",
+			steps:     []FlowStep{While(countdown(2), []FlowStep{noop()}, 0)},
+			total:     3,
+			completed: 3,
+		},
+		{
+			name:      "while max iterations",
+			steps:     []FlowStep{While(countdown(5), []FlowStep{noop()}, 2)},
+			total:     3,
+			completed: 2,
+			err:       ErrMaxIterations,
+		},
+		{
+			name: "for each",
+			steps: []FlowStep{ForEach([]string{"a", "b"}, func(string) []FlowStep {
+				return []FlowStep{noop(), noop()}
+			})},
+			total:     5,
+			completed: 5,
+		},
+		{
+			name:      "nested failure",
+			steps:     []FlowStep{Repeat(2, []FlowStep{noop(), flakyStep(1)}), noop()},
+			total:     4,
+			completed: 1,
+			err:       errFlaky,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, err := NewFlow().Load(tt.steps).RunOn(context.Background(), nil)
+			if !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+				t.Fatalf("RunOn() error = %v, want %v", err, tt.err)
+			}
+
+			if state.StepsCount != tt.total || state.CompletedSteps != tt.completed {
+				t.Errorf("counters = %d/%d, want %d/%d", state.CompletedSteps, state.StepsCount, tt.completed, tt.total)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package screenflow
+
+import (
+	"context"
+	"image"
+	"time"
+
+	"github.com/merzzzl/screen-flow/device"
+	"github.com/merzzzl/screen-flow/vision"
+)
+
+type Observer interface {
+	OnFlowStart(state *FlowState)
+	OnStepStart(state *FlowState, step *StepResult)
+	OnStepEnd(state *FlowState, step *StepResult)
+	OnRetry(step *StepResult, attempt int, err error)
+	OnFrame(frame vision.Frame)
+	OnFlowEnd(state *FlowState, err error)
+}
+
+type NopObserver struct{}
+
+type EventType int
+
+const (
+	EventFlowStart EventType = iota
+	EventStepStart
+	EventStepEnd
+	EventRetry
+	EventFrame
+	EventFlowEnd
+)
+
+type Event struct {
+	Type    EventType
+	At      time.Time
+	Flow    *FlowState
+	Step    *StepResult
+	Attempt int
+	Err     error
+	Frame   image.Image
+}
+
+type chanObserver struct {
+	ctx    context.Context
+	events chan<- Event
+	frames bool
+}
+
+type observers []Observer
+
+type observersKey struct{}
+
+func (NopObserver) OnFlowStart(*FlowState)              {}
+func (NopObserver) OnStepStart(*FlowState, *StepResult) {}
+func (NopObserver) OnStepEnd(*FlowState, *StepResult)   {}
+func (NopObserver) OnRetry(*StepResult, int, error)     {}
+func (NopObserver) OnFrame(vision.Frame)                {}
+func (NopObserver) OnFlowEnd(*FlowState, error)         {}
+
+func ChanObserver(ctx context.Context, events chan<- Event, frames bool) Observer {
+	return &chanObserver{
+		ctx:    ctx,
+		events: events,
+		frames: frames,
+	}
+}
+
+func (o *chanObserver) OnFlowStart(state *FlowState) {
+	o.send(Event{Type: EventFlowStart, At: time.Now(), Flow: state})
+}
+
+func (o *chanObserver) OnStepStart(state *FlowState, step *StepResult) {
+	o.send(Event{Type: EventStepStart, At: time.Now(), Flow: state, Step: step})
+}
+
+func (o *chanObserver) OnStepEnd(state *FlowState, step *StepResult) {
+	o.send(Event{Type: EventStepEnd, At: time.Now(), Flow: state, Step: step, Err: step.Err})
+}
+
+func (o *chanObserver) OnRetry(step *StepResult, attempt int, err error) {
+	o.send(Event{Type: EventRetry, At: time.Now(), Step: step, Attempt: attempt, Err: err})
+}
+
+func (o *chanObserver) OnFrame(frame vision.Frame) {
+	if !o.frames {
+		return
+	}
+
+	img, err := frame.Image()
+	if err != nil {
+		return
+	}
+
+	select {
+	case o.events <- Event{Type: EventFrame, At: frame.At, Frame: img}:
+	default:
+	}
+}
+
+func (o *chanObserver) OnFlowEnd(state *FlowState, err error) {
+	o.send(Event{Type: EventFlowEnd, At: time.Now(), Flow: state, Err: err})
+}
+
+func (o *chanObserver) send(event Event) {
+	select {
+	case o.events <- event:
+	case <-o.ctx.Done():
+	}
+}
+
+func (f *Flow) Observe(observer Observer) *Flow {
+	f.observers = append(f.observers, observer)
+
+	return f
+}
+
+func (f *Flow) observe(ctx context.Context, conn *device.Conn) (context.Context, observers, func()) {
+	parent, _ := ctx.Value(observersKey{}).(observers)
+
+	if len(f.observers) == 0 {
+		return ctx, parent, func() {}
+	}
+
+	all := make(observers, 0, len(parent)+len(f.observers))
+	all = append(all, parent...)
+	all = append(all, f.observers...)
+
+	own := observers(f.observers)
+	cancel := conn.GetVision().OnFrame(own.OnFrame)
+
+	return context.WithValue(ctx, observersKey{}, all), all, cancel
+}
+
+func observersFrom(ctx context.Context) observers {
+	obs, _ := ctx.Value(observersKey{}).(observers)
+
+	return obs
+}
+
+func (o observers) OnFlowStart(state *FlowState) {
+	for _, obs := range o {
+		obs.OnFlowStart(state)
+	}
+}
+
+func (o observers) OnStepStart(state *FlowState, step *StepResult) {
+	for _, obs := range o {
+		obs.OnStepStart(state, step)
+	}
+}
+
+func (o observers) OnStepEnd(state *FlowState, step *StepResult) {
+	for _, obs := range o {
+		obs.OnStepEnd(state, step)
+	}
+}
+
+func (o observers) OnRetry(step *StepResult, attempt int, err error) {
+	for _, obs := range o {
+		obs.OnRetry(step, attempt, err)
+	}
+}
+
+func (o observers) OnFrame(frame vision.Frame) {
+	for _, obs := range o {
+		obs.OnFrame(frame)
+	}
+}
+
+func (o observers) OnFlowEnd(state *FlowState, err error) {
+	for _, obs := range o {
+		obs.OnFlowEnd(state, err)
+	}
+}
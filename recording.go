@@ -0,0 +1,84 @@
+package screenflow
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/merzzzl/screen-flow/device"
+)
+
+func (f *Flow) WithRecording(path string) *Flow {
+	f.recording = path
+
+	return f
+}
+
+func (f *Flow) startRecording(conn *device.Conn, state *FlowState) (func() error, error) {
+	if f.recording == "" {
+		return func() error { return nil }, nil
+	}
+
+	rec, err := conn.GetSCRCPY().Record(f.recording)
+	if err != nil {
+		return nil, fmt.Errorf("start recording: %w", err)
+	}
+
+	state.Video = rec.Path()
+
+	return func() error {
+		var errs []error
+
+		if err := rec.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("stop recording: %w", err))
+		}
+
+		chapters := strings.TrimSuffix(rec.Path(), filepath.Ext(rec.Path())) + ".chapters.txt"
+
+		if err := writeChapters(chapters, rec.StartAt(), state); err != nil {
+			errs = append(errs, fmt.Errorf("write chapters: %w", err))
+		} else {
+			state.Chapters = chapters
+		}
+
+		return errors.Join(errs...)
+	}, nil
+}
+
+func writeChapters(path string, startAt time.Time, state *FlowState) error {
+	var b strings.Builder
+
+	b.WriteString(";FFMETADATA1\n")
+
+	if startAt.IsZero() {
+		return os.WriteFile(path, []byte(b.String()), 0o644)
+	}
+
+	for _, step := range state.TopSteps() {
+		endAt := step.EndAt
+		if endAt.IsZero() {
+			endAt = time.Now()
+		}
+
+		fmt.Fprintf(&b, "\n[CHAPTER]\nTIMEBASE=1/1000\nSTART=%d\nEND=%d\ntitle=%s\n",
+			max(step.StartAt.Sub(startAt).Milliseconds(), 0),
+			max(endAt.Sub(startAt).Milliseconds(), 0),
+			chapterTitle(step),
+		)
+	}
+
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
+
+func chapterTitle(step *StepResult) string {
+	title := step.Path + " " + step.Kind
+
+	if step.Err != nil {
+		title += " (failed)"
+	}
+
+	return strings.NewReplacer("=", "\\=", ";", "\\;", "#", "\\#", "\\", "\\\\", "\n", " ").Replace(title)
+}
@@ -0,0 +1,124 @@
+package screenflow
+
+import (
+	"bytes"
+	"image/png"
+	"time"
+
+	"github.com/merzzzl/screen-flow/report"
+)
+
+func NewReport(states ...*FlowState) *report.Report {
+	flows := make([]*report.Flow, 0, len(states))
+
+	for _, state := range states {
+		if state != nil {
+			flows = append(flows, newReportFlow(state))
+		}
+	}
+
+	return report.New(flows...)
+}
+
+func newReportFlow(state *FlowState) *report.Flow {
+	f := &report.Flow{
+		Name:       state.Name,
+		StartAt:    reportTime(state.StartAt),
+		EndAt:      reportTime(state.EndAt),
+		DurationMs: state.EndAt.Sub(state.StartAt).Milliseconds(),
+		StepsCount: state.StepsCount,
+		Completed:  state.CompletedSteps,
+		Passed:     state.Err == nil,
+		Video:      state.Video,
+		Chapters:   state.Chapters,
+		Steps:      make([]*report.Step, 0, len(state.Steps)),
+	}
+
+	if f.Name == "" {
+		f.Name = "Flow"
+	}
+
+	if state.Err != nil {
+		f.Error = state.Err.Error()
+	}
+
+	for _, res := range state.Steps {
+		f.Steps = append(f.Steps, newReportStep(res))
+	}
+
+	for _, sub := range state.SubFlows {
+		f.SubFlows = append(f.SubFlows, newReportFlow(sub))
+	}
+
+	return f
+}
+
+func newReportStep(res *StepResult) *report.Step {
+	s := &report.Step{
+		Index:       res.Index,
+		Path:        res.Path,
+		Depth:       res.Depth,
+		Kind:        res.Kind,
+		Params:      res.Params,
+		StartAt:     reportTime(res.StartAt),
+		EndAt:       reportTime(res.EndAt),
+		DurationMs:  res.Duration().Milliseconds(),
+		Attempts:    res.Attempts,
+		Passed:      res.Err == nil && !res.EndAt.IsZero(),
+		Screenshots: res.Screenshots,
+		ScreenDump:  res.ScreenDump,
+		Frame:       res.Frame,
+	}
+
+	if res.Err != nil {
+		s.Error = res.Err.Error()
+	}
+
+	if res.Point != nil {
+		s.Point = &report.Point{X: res.Point.X, Y: res.Point.Y}
+	}
+
+	if res.Match != nil {
+		s.Match = &report.Match{
+			Algorithm:  res.Match.Algorithm.String(),
+			X:          res.Match.Point.X,
+			Y:          res.Match.Point.Y,
+			Rect:       [4]int{res.Match.Rect.Min.X, res.Match.Rect.Min.Y, res.Match.Rect.Max.X, res.Match.Rect.Max.Y},
+			Score:      res.Match.Score,
+			DurationMs: float64(res.Match.Duration.Microseconds()) / 1000,
+			Pairs:      make([][4]int, 0, len(res.Match.Pairs)),
+		}
+
+		for _, p := range res.Match.Pairs {
+			s.Match.Pairs = append(s.Match.Pairs, [4]int{p.Src.X, p.Src.Y, p.Tpl.X, p.Tpl.Y})
+		}
+	}
+
+	if res.Template != nil {
+		var buf bytes.Buffer
+
+		if err := png.Encode(&buf, res.Template); err == nil {
+			s.Template = buf.Bytes()
+		}
+	}
+
+	if res.Element != nil {
+		b := res.Element.Bounds
+		s.Element = &report.Element{
+			Text:      res.Element.Text,
+			UniqueID:  res.Element.UniqueID,
+			ClassName: res.Element.ClassName,
+			Bounds:    [4]int{b.Min.X, b.Min.Y, b.Max.X, b.Max.Y},
+		}
+	}
+
+	return s
+}
+
+func reportTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339Nano)
+}
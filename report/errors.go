@@ -0,0 +1,7 @@
+package report
+
+import "errors"
+
+var ErrUnsupportedVersion = errors.New("unsupported report version")
+
+var ErrUnknownFormat = errors.New("unknown format")
@@ -0,0 +1,268 @@
+package report
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+)
+
+//go:embed html.tmpl
+var htmlSource string
+
+var htmlTemplate = template.Must(template.New("report").Parse(htmlSource))
+
+type htmlReport struct {
+	StartAt string
+	Passed  int
+	Failed  int
+	Flows   []htmlFlow
+}
+
+type htmlFlow struct {
+	Name       string
+	Passed     bool
+	Duration   string
+	Completed  int
+	StepsCount int
+	Error      string
+	Video      string
+	Timeline   []htmlBar
+	Steps      []htmlStep
+}
+
+type htmlBar struct {
+	Label  string
+	Passed bool
+	Width  float64
+}
+
+type htmlStep struct {
+	Path        string
+	Kind        string
+	Depth       int
+	Passed      bool
+	Duration    string
+	Attempts    int
+	Error       string
+	Params      []htmlParam
+	Match       *Match
+	Scene       *htmlScene
+	Screenshots []string
+	ScreenDump  string
+}
+
+type htmlParam struct {
+	Name  string
+	Value string
+}
+
+type htmlScene struct {
+	Width    int
+	Height   int
+	Frame    template.URL
+	FrameW   int
+	FrameH   int
+	Template template.URL
+	TplX     int
+	TplW     int
+	TplH     int
+	Point    *image.Point
+	Rect     *image.Rectangle
+	Pairs    []htmlPair
+	Element  *image.Rectangle
+}
+
+type htmlPair struct {
+	X1, Y1, X2, Y2 int
+}
+
+func (r *Report) WriteHTML(w io.Writer) error {
+	view := htmlReport{
+		StartAt: r.StartAt,
+	}
+
+	for _, f := range r.Flows {
+		if f.Passed {
+			view.Passed++
+		} else {
+			view.Failed++
+		}
+
+		view.Flows = append(view.Flows, htmlFlows(f, "")...)
+	}
+
+	var buf bytes.Buffer
+
+	if err := htmlTemplate.Execute(&buf, view); err != nil {
+		return fmt.Errorf("render html: %w", err)
+	}
+
+	if _, err := buf.WriteTo(w); err != nil {
+		return fmt.Errorf("write html: %w", err)
+	}
+
+	return nil
+}
+
+func htmlFlows(f *Flow, parent string) []htmlFlow {
+	name := f.Name
+	if parent != "" {
+		name = parent + " > " + name
+	}
+
+	out := htmlFlow{
+		Name:       name,
+		Passed:     f.Passed,
+		Duration:   duration(f.DurationMs),
+		Completed:  f.Completed,
+		StepsCount: f.StepsCount,
+		Error:      f.Error,
+		Video:      f.Video,
+	}
+
+	top := f.TopSteps()
+
+	var total int64
+
+	for _, step := range top {
+		total += step.DurationMs
+	}
+
+	for _, step := range top {
+		width := 100 / float64(len(top))
+		if total > 0 {
+			width = 100 * float64(step.DurationMs) / float64(total)
+		}
+
+		out.Timeline = append(out.Timeline, htmlBar{
+			Label:  fmt.Sprintf("%s %s (%s)", step.Path, step.Kind, duration(step.DurationMs)),
+			Passed: step.Error == "",
+			Width:  width,
+		})
+	}
+
+	depth := 0
+	if len(top) > 0 {
+		depth = top[0].Depth
+	}
+
+	for _, step := range f.Steps {
+		out.Steps = append(out.Steps, htmlStep{
+			Path:        step.Path,
+			Kind:        step.Kind,
+			Depth:       step.Depth - depth,
+			Passed:      step.Error == "",
+			Duration:    duration(step.DurationMs),
+			Attempts:    step.Attempts,
+			Error:       step.Error,
+			Params:      htmlParams(step.Params),
+			Match:       step.Match,
+			Scene:       htmlStepScene(step),
+			Screenshots: step.Screenshots,
+			ScreenDump:  step.ScreenDump,
+		})
+	}
+
+	flows := []htmlFlow{out}
+
+	for _, sub := range f.SubFlows {
+		flows = append(flows, htmlFlows(sub, name)...)
+	}
+
+	return flows
+}
+
+func htmlParams(params map[string]string) []htmlParam {
+	out := make([]htmlParam, 0, len(params))
+
+	for name, value := range params {
+		out = append(out, htmlParam{Name: name, Value: value})
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+
+	return out
+}
+
+func htmlStepScene(step *Step) *htmlScene {
+	frame := step.Frame
+
+	if frame == nil {
+		for _, path := range step.Screenshots {
+			if data, err := os.ReadFile(path); err == nil {
+				frame = data
+
+				break
+			}
+		}
+	}
+
+	if frame == nil && step.Template == nil {
+		return nil
+	}
+
+	scene := &htmlScene{}
+
+	if frame != nil {
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(frame))
+		if err == nil {
+			scene.Frame = dataURL(frame)
+			scene.FrameW, scene.FrameH = cfg.Width, cfg.Height
+		}
+	}
+
+	if step.Template != nil {
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(step.Template))
+		if err == nil {
+			scene.Template = dataURL(step.Template)
+			scene.TplW, scene.TplH = cfg.Width, cfg.Height
+		}
+	}
+
+	if scene.FrameW > 0 && scene.TplW > 0 {
+		scene.TplX = scene.FrameW + 40
+	}
+
+	scene.Width = max(scene.FrameW, scene.TplX+scene.TplW)
+	scene.Height = max(scene.FrameH, scene.TplH)
+
+	if scene.Width == 0 || scene.Height == 0 {
+		return nil
+	}
+
+	if scene.FrameW > 0 && step.Match != nil {
+		scene.Point = &image.Point{X: step.Match.X, Y: step.Match.Y}
+
+		if r := step.Match.Rect; r[2] > r[0] && r[3] > r[1] {
+			rect := image.Rect(r[0], r[1], r[2], r[3])
+			scene.Rect = &rect
+		}
+
+		if scene.TplW > 0 {
+			for _, p := range step.Match.Pairs {
+				scene.Pairs = append(scene.Pairs, htmlPair{X1: p[0], Y1: p[1], X2: scene.TplX + p[2], Y2: p[3]})
+			}
+		}
+	}
+
+	if scene.FrameW > 0 && step.Element != nil {
+		b := step.Element.Bounds
+		rect := image.Rect(b[0], b[1], b[2], b[3])
+		scene.Element = &rect
+	}
+
+	return scene
+}
+
+func dataURL(data []byte) template.URL {
+	return template.URL("data:" + http.DetectContentType(data) + ";base64," + base64.StdEncoding.EncodeToString(data))
+}
@@ -0,0 +1,189 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Time     string       `xml:"time,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name      string      `xml:"name,attr"`
+	Tests     int         `xml:"tests,attr"`
+	Failures  int         `xml:"failures,attr"`
+	Time      string      `xml:"time,attr"`
+	Timestamp string      `xml:"timestamp,attr,omitempty"`
+	Cases     []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func (r *Report) WriteJUnit(w io.Writer) error {
+	out := junitSuites{}
+
+	var total int64
+
+	for _, f := range r.Flows {
+		for _, suite := range junitFlow(f, "") {
+			out.Tests += suite.Tests
+			out.Failures += suite.Failures
+			out.Suites = append(out.Suites, suite)
+		}
+
+		total += f.DurationMs
+	}
+
+	out.Time = seconds(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	if err := enc.Encode(out); err != nil {
+		return fmt.Errorf("encode junit: %w", err)
+	}
+
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("write junit: %w", err)
+	}
+
+	return nil
+}
+
+func junitFlow(f *Flow, parent string) []junitSuite {
+	name := f.Name
+	if parent != "" {
+		name = parent + " > " + name
+	}
+
+	suite := junitSuite{
+		Name:      name,
+		Time:      seconds(f.DurationMs),
+		Timestamp: f.StartAt,
+	}
+
+	var delegated bool
+
+	for _, step := range f.TopSteps() {
+		c := junitCase{
+			Name:      step.Path + " " + step.Kind,
+			ClassName: name,
+			Time:      seconds(step.DurationMs),
+		}
+
+		if sub := failedSubFlow(f, step); sub != nil {
+			c.Skipped = &junitSkipped{Message: "failed in sub-flow " + name + " > " + sub.Name}
+			delegated = true
+		} else if step.Error != "" {
+			c.Failure = &junitFailure{
+				Message: step.Error,
+				Type:    step.Kind,
+				Text:    failureText(step),
+			}
+
+			suite.Failures++
+		}
+
+		suite.Cases = append(suite.Cases, c)
+	}
+
+	if f.Error != "" && suite.Failures == 0 && !delegated {
+		suite.Cases = append(suite.Cases, junitCase{
+			Name:      "flow",
+			ClassName: name,
+			Time:      seconds(f.DurationMs),
+			Failure: &junitFailure{
+				Message: f.Error,
+				Type:    "Flow",
+			},
+		})
+
+		suite.Failures++
+	}
+
+	suite.Tests = len(suite.Cases)
+
+	out := []junitSuite{suite}
+
+	for _, sub := range f.SubFlows {
+		out = append(out, junitFlow(sub, name)...)
+	}
+
+	return out
+}
+
+func failedSubFlow(f *Flow, step *Step) *Flow {
+	if step.Error == "" {
+		return nil
+	}
+
+	start, err1 := time.Parse(time.RFC3339Nano, step.StartAt)
+	end, err2 := time.Parse(time.RFC3339Nano, step.EndAt)
+
+	if err1 != nil || err2 != nil {
+		return nil
+	}
+
+	for _, sub := range f.SubFlows {
+		if sub.Passed {
+			continue
+		}
+
+		subStart, err1 := time.Parse(time.RFC3339Nano, sub.StartAt)
+		subEnd, err2 := time.Parse(time.RFC3339Nano, sub.EndAt)
+
+		if err1 == nil && err2 == nil && !subStart.Before(start) && !subEnd.After(end) {
+			return sub
+		}
+	}
+
+	return nil
+}
+
+func failureText(step *Step) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s\nattempts: %d\n", step.Error, step.Attempts)
+
+	for _, path := range step.Screenshots {
+		fmt.Fprintf(&b, "screenshot: %s\n", path)
+	}
+
+	if step.ScreenDump != "" {
+		fmt.Fprintf(&b, "screen dump: %s\n", step.ScreenDump)
+	}
+
+	return b.String()
+}
+
+func seconds(ms int64) string {
+	return fmt.Sprintf("%.3f", float64(ms)/1000)
+}
@@ -0,0 +1,185 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+const SchemaVersion = 1
+
+type Report struct {
+	Version int     `json:"version"`
+	StartAt string  `json:"start_at"`
+	Flows   []*Flow `json:"flows"`
+}
+
+type Flow struct {
+	Name       string  `json:"name"`
+	StartAt    string  `json:"start_at"`
+	EndAt      string  `json:"end_at"`
+	DurationMs int64   `json:"duration_ms"`
+	StepsCount int     `json:"steps_count"`
+	Completed  int     `json:"completed_steps"`
+	Passed     bool    `json:"passed"`
+	Error      string  `json:"error,omitempty"`
+	Video      string  `json:"video,omitempty"`
+	Chapters   string  `json:"chapters,omitempty"`
+	Steps      []*Step `json:"steps"`
+	SubFlows   []*Flow `json:"sub_flows,omitempty"`
+}
+
+type Step struct {
+	Index       int               `json:"index"`
+	Path        string            `json:"path"`
+	Depth       int               `json:"depth"`
+	Kind        string            `json:"kind"`
+	Params      map[string]string `json:"params,omitempty"`
+	StartAt     string            `json:"start_at"`
+	EndAt       string            `json:"end_at"`
+	DurationMs  int64             `json:"duration_ms"`
+	Attempts    int               `json:"attempts"`
+	Passed      bool              `json:"passed"`
+	Error       string            `json:"error,omitempty"`
+	Point       *Point            `json:"point,omitempty"`
+	Match       *Match            `json:"match,omitempty"`
+	Element     *Element          `json:"element,omitempty"`
+	Screenshots []string          `json:"screenshots,omitempty"`
+	ScreenDump  string            `json:"screen_dump,omitempty"`
+	Frame       []byte            `json:"-"`
+	Template    []byte            `json:"-"`
+}
+
+type Point struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type Match struct {
+	Algorithm  string   `json:"algorithm"`
+	X          int      `json:"x"`
+	Y          int      `json:"y"`
+	Rect       [4]int   `json:"rect"`
+	Score      float64  `json:"score"`
+	DurationMs float64  `json:"duration_ms"`
+	Pairs      [][4]int `json:"pairs,omitempty"`
+}
+
+type Element struct {
+	Text      string `json:"text,omitempty"`
+	UniqueID  string `json:"unique_id,omitempty"`
+	ClassName string `json:"class_name,omitempty"`
+	Bounds    [4]int `json:"bounds"`
+}
+
+func New(flows ...*Flow) *Report {
+	r := &Report{
+		Version: SchemaVersion,
+		Flows:   make([]*Flow, 0, len(flows)),
+	}
+
+	var startAt time.Time
+
+	for _, f := range flows {
+		if f == nil {
+			continue
+		}
+
+		if t, err := time.Parse(time.RFC3339Nano, f.StartAt); err == nil && (startAt.IsZero() || t.Before(startAt)) {
+			startAt = t
+		}
+
+		r.Flows = append(r.Flows, f)
+	}
+
+	r.StartAt = formatTime(startAt)
+
+	return r
+}
+
+func Read(rd io.Reader) (*Report, error) {
+	var r Report
+
+	if err := json.NewDecoder(rd).Decode(&r); err != nil {
+		return nil, fmt.Errorf("decode report: %w", err)
+	}
+
+	if r.Version != SchemaVersion {
+		return nil, fmt.Errorf("version %d: %w", r.Version, ErrUnsupportedVersion)
+	}
+
+	return &r, nil
+}
+
+func Merge(reports ...*Report) *Report {
+	out := &Report{
+		Version: SchemaVersion,
+		Flows:   make([]*Flow, 0),
+	}
+
+	var startAt time.Time
+
+	for _, r := range reports {
+		if t, err := time.Parse(time.RFC3339Nano, r.StartAt); err == nil && (startAt.IsZero() || t.Before(startAt)) {
+			startAt = t
+		}
+
+		out.Flows = append(out.Flows, r.Flows...)
+	}
+
+	out.StartAt = formatTime(startAt)
+
+	return out
+}
+
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(r); err != nil {
+		return fmt.Errorf("encode report: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Report) Passed() bool {
+	for _, f := range r.Flows {
+		if !f.Passed {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f *Flow) TopSteps() []*Step {
+	if len(f.Steps) == 0 {
+		return nil
+	}
+
+	depth := f.Steps[0].Depth
+
+	for _, step := range f.Steps {
+		depth = min(depth, step.Depth)
+	}
+
+	out := make([]*Step, 0, len(f.Steps))
+
+	for _, step := range f.Steps {
+		if step.Depth == depth {
+			out = append(out, step)
+		}
+	}
+
+	return out
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,277 @@
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func passedFlow() *Flow {
+	return &Flow{
+		Name:       "login",
+		StartAt:    "2024-05-01T10:00:01Z",
+		EndAt:      "2024-05-01T10:00:03Z",
+		DurationMs: 2000,
+		StepsCount: 2,
+		Completed:  2,
+		Passed:     true,
+		Steps: []*Step{
+			{Index: 0, Path: "step 0", Kind: "TapXY", StartAt: "2024-05-01T10:00:01Z", EndAt: "2024-05-01T10:00:02Z", DurationMs: 1000, Attempts: 1, Passed: true},
+			{Index: 1, Path: "step 1", Kind: "Type", StartAt: "2024-05-01T10:00:02Z", EndAt: "2024-05-01T10:00:03Z", DurationMs: 1000, Attempts: 2, Passed: true},
+		},
+	}
+}
+
+func failedFlow() *Flow {
+	return &Flow{
+		Name:       "checkout",
+		StartAt:    "2024-05-01T10:00:00Z",
+		EndAt:      "2024-05-01T10:00:05Z",
+		DurationMs: 5000,
+		StepsCount: 3,
+		Completed:  1,
+		Error:      "step 1 failed: pay step 0 failed: image not found",
+		Steps: []*Step{
+			{Index: 0, Path: "step 0", Kind: "TapXY", StartAt: "2024-05-01T10:00:00Z", EndAt: "2024-05-01T10:00:01Z", DurationMs: 1000, Attempts: 1, Passed: true},
+			{Index: 1, Path: "step 1", Kind: "Flow", StartAt: "2024-05-01T10:00:01Z", EndAt: "2024-05-01T10:00:05Z", DurationMs: 4000, Attempts: 1, Error: "pay step 0 failed: image not found"},
+		},
+		SubFlows: []*Flow{
+			{
+				Name:       "pay",
+				StartAt:    "2024-05-01T10:00:01Z",
+				EndAt:      "2024-05-01T10:00:05Z",
+				DurationMs: 4000,
+				StepsCount: 1,
+				Error:      "step 0 failed: image not found",
+				Steps: []*Step{
+					{
+						Index: 0, Path: "step 0", Kind: "TapImage",
+						StartAt: "2024-05-01T10:00:01Z", EndAt: "2024-05-01T10:00:05Z", DurationMs: 4000,
+						Attempts: 3, Error: "image not found", Screenshots: []string{"pay-step-0.png"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestNew(t *testing.T) {
+	r := New(passedFlow(), nil, failedFlow())
+
+	if r.Version != SchemaVersion {
+		t.Errorf("Version = %d, want %d", r.Version, SchemaVersion)
+	}
+
+	if len(r.Flows) != 2 {
+		t.Errorf("Flows = %d, want 2", len(r.Flows))
+	}
+
+	if r.StartAt != "2024-05-01T10:00:00Z" {
+		t.Errorf("StartAt = %q, want earliest flow start", r.StartAt)
+	}
+
+	if r.Passed() {
+		t.Errorf("Passed() = true, want false")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	r := Merge(New(passedFlow()), New(failedFlow()))
+
+	if len(r.Flows) != 2 || r.Flows[0].Name != "login" || r.Flows[1].Name != "checkout" {
+		t.Errorf("Flows = %v, want login, checkout", r.Flows)
+	}
+
+	if r.StartAt != "2024-05-01T10:00:00Z" {
+		t.Errorf("StartAt = %q, want earliest report start", r.StartAt)
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		report *Report
+	}{
+		{name: "empty", report: New()},
+		{name: "passed", report: New(passedFlow())},
+		{name: "failed with sub-flow", report: New(passedFlow(), failedFlow())},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			if err := tt.report.WriteJSON(&buf); err != nil {
+				t.Fatalf("WriteJSON() error = %v", err)
+			}
+
+			got, err := Read(&buf)
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+
+			if got.Version != tt.report.Version || got.StartAt != tt.report.StartAt || len(got.Flows) != len(tt.report.Flows) {
+				t.Fatalf("Read() = %+v, want %+v", got, tt.report)
+			}
+
+			for i := range got.Flows {
+				if !reflect.DeepEqual(got.Flows[i], tt.report.Flows[i]) {
+					t.Errorf("flow %d = %+v, want %+v", i, got.Flows[i], tt.report.Flows[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		wantErr error
+	}{
+		{name: "current version", in: `{"version": 1, "flows": []}`},
+		{name: "unsupported version", in: `{"version": 99, "flows": []}`, wantErr: ErrUnsupportedVersion},
+		{name: "missing version", in: `{"flows": []}`, wantErr: ErrUnsupportedVersion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(tt.in))
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("Read() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := Read(strings.NewReader("{")); err == nil {
+		t.Errorf("Read() of invalid JSON error = nil, want error")
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	type suite struct {
+		name     string
+		tests    int
+		failures int
+	}
+
+	tests := []struct {
+		name     string
+		report   *Report
+		tests    int
+		failures int
+		suites   []suite
+	}{
+		{
+			name:   "passed",
+			report: New(passedFlow()),
+			tests:  2,
+			suites: []suite{{name: "login", tests: 2}},
+		},
+		{
+			name:     "sub-flow failure counted once",
+			report:   New(failedFlow()),
+			tests:    3,
+			failures: 1,
+			suites: []suite{
+				{name: "checkout", tests: 2},
+				{name: "checkout > pay", tests: 1, failures: 1},
+			},
+		},
+		{
+			name: "flow error without failed step",
+			report: New(&Flow{
+				Name:       "timeout",
+				DurationMs: 100,
+				Error:      "flow deadline 100ms exceeded",
+			}),
+			tests:    1,
+			failures: 1,
+			suites:   []suite{{name: "timeout", tests: 1, failures: 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			if err := tt.report.WriteJUnit(&buf); err != nil {
+				t.Fatalf("WriteJUnit() error = %v", err)
+			}
+
+			var got junitSuites
+			if err := xml.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("unmarshal junit: %v", err)
+			}
+
+			if got.Tests != tt.tests || got.Failures != tt.failures {
+				t.Errorf("totals = %d tests, %d failures, want %d, %d", got.Tests, got.Failures, tt.tests, tt.failures)
+			}
+
+			if len(got.Suites) != len(tt.suites) {
+				t.Fatalf("suites = %d, want %d", len(got.Suites), len(tt.suites))
+			}
+
+			for i, want := range tt.suites {
+				s := got.Suites[i]
+
+				if s.Name != want.name || s.Tests != want.tests || s.Failures != want.failures {
+					t.Errorf("suite %d = %s %d/%d, want %s %d/%d", i, s.Name, s.Failures, s.Tests, want.name, want.failures, want.tests)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	tests := []struct {
+		name   string
+		report *Report
+		want   []string
+	}{
+		{
+			name:   "passed",
+			report: New(passedFlow()),
+			want: []string{
+				"PASS login (2/2 steps, 2s)\n",
+				"  step 1 Type: 2 attempts, 1s\n",
+				"\n1 flows: 1 passed, 0 failed\n",
+			},
+		},
+		{
+			name:   "failed with sub-flow",
+			report: New(failedFlow()),
+			want: []string{
+				"FAIL checkout (1/3 steps, 5s)\n",
+				"    error: pay step 0 failed: image not found\n",
+				"  FAIL pay (0/1 steps, 4s)\n",
+				"    step 0 TapImage: 3 attempts, 4s\n",
+				"      screenshot: pay-step-0.png\n",
+				"\n1 flows: 0 passed, 1 failed\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			if err := tt.report.WriteText(&buf); err != nil {
+				t.Fatalf("WriteText() error = %v", err)
+			}
+
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("WriteText() = %q, missing %q", buf.String(), want)
+				}
+			}
+
+			if strings.Contains(buf.String(), "step 0 TapXY") {
+				t.Errorf("WriteText() lists a passed single-attempt step")
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+func (r *Report) WriteText(w io.Writer) error {
+	var (
+		b      strings.Builder
+		passed int
+	)
+
+	for _, f := range r.Flows {
+		writeFlowText(&b, f, "")
+
+		if f.Passed {
+			passed++
+		}
+	}
+
+	fmt.Fprintf(&b, "\n%d flows: %d passed, %d failed\n", len(r.Flows), passed, len(r.Flows)-passed)
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("write text: %w", err)
+	}
+
+	return nil
+}
+
+func writeFlowText(b *strings.Builder, f *Flow, indent string) {
+	status := "PASS"
+	if !f.Passed {
+		status = "FAIL"
+	}
+
+	fmt.Fprintf(b, "%s%s %s (%d/%d steps, %s)\n",
+		indent, status, f.Name, f.Completed, f.StepsCount, duration(f.DurationMs))
+
+	for _, step := range f.Steps {
+		if step.Error == "" && step.Attempts <= 1 {
+			continue
+		}
+
+		fmt.Fprintf(b, "%s  %s %s: %d attempts, %s\n",
+			indent, step.Path, step.Kind, step.Attempts, duration(step.DurationMs))
+
+		if step.Error != "" {
+			fmt.Fprintf(b, "%s    error: %s\n", indent, step.Error)
+		}
+
+		for _, path := range step.Screenshots {
+			fmt.Fprintf(b, "%s    screenshot: %s\n", indent, path)
+		}
+
+		if step.ScreenDump != "" {
+			fmt.Fprintf(b, "%s    screen dump: %s\n", indent, step.ScreenDump)
+		}
+	}
+
+	if f.Video != "" {
+		fmt.Fprintf(b, "%s  video: %s\n", indent, f.Video)
+	}
+
+	for _, sub := range f.SubFlows {
+		writeFlowText(b, sub, indent+"  ")
+	}
+}
+
+func duration(ms int64) string {
+	return (time.Duration(ms) * time.Millisecond).String()
+}
@@ -0,0 +1,291 @@
+package screenflow
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"log/slog"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/merzzzl/screen-flow/actions"
+	"github.com/merzzzl/screen-flow/device"
+	"github.com/merzzzl/screen-flow/vision"
+)
+
+type StepResult struct {
+	Index       int
+	Path        string
+	Depth       int
+	Kind        string
+	Params      map[string]string
+	StartAt     time.Time
+	EndAt       time.Time
+	Attempts    int
+	Err         error
+	Point       *image.Point
+	Match       *vision.Match
+	Template    image.Image
+	Frame       []byte
+	Element     *actions.Element
+	Screenshots []string
+	ScreenDump  string
+
+	record    *actions.Record
+	state     *FlowState
+	observers observers
+	log       *slog.Logger
+	artifacts *artifacts
+	frames    *device.Conn
+}
+
+type pathKey struct{}
+
+type resultKey struct{}
+
+func (s *FlowState) beginStep(ctx context.Context, index int, name string, step FlowStep) (context.Context, *StepResult) {
+	path := fmt.Sprintf("step %d", index)
+
+	if name != "" {
+		path = fmt.Sprintf("%s step %d", name, index)
+	}
+
+	if parent, ok := ctx.Value(pathKey{}).(string); ok {
+		path = parent + " > " + path
+	}
+
+	res := &StepResult{
+		Index:     index,
+		Path:      path,
+		Depth:     strings.Count(path, " > "),
+		Kind:      stepKind(step),
+		Params:    stepParams(step),
+		StartAt:   time.Now(),
+		Attempts:  1,
+		record:    &actions.Record{},
+		state:     s,
+		observers: observersFrom(ctx),
+	}
+
+	res.artifacts, _ = ctx.Value(artifactsKey{}).(*artifacts)
+	res.frames, _ = ctx.Value(framesKey{}).(*device.Conn)
+
+	res.log = actions.LoggerFromContext(ctx, nil).With("step", path, "kind", res.Kind)
+
+	if s != nil {
+		s.Steps = append(s.Steps, res)
+	}
+
+	ctx = context.WithValue(ctx, pathKey{}, path)
+	ctx = context.WithValue(ctx, resultKey{}, res)
+	ctx = actions.WithRecord(ctx, res.record)
+	ctx = actions.WithLogger(ctx, res.log)
+
+	res.log.Debug("step started", "params", res.Params)
+	res.observers.OnStepStart(s, res)
+
+	return ctx, res
+}
+
+func (r *StepResult) deadline(ctx context.Context, err error) error {
+	if r == nil {
+		return err
+	}
+
+	return deadlineError(ctx, r.Index, r.Path, r.Kind, err)
+}
+
+func (r *StepResult) end(attempts int, err error) {
+	r.EndAt = time.Now()
+	r.Attempts = attempts
+	r.Err = err
+
+	if r.frames != nil {
+		frame, frameErr := captureFrame(r.frames, r.record.Frame)
+		if frameErr != nil {
+			r.log.Warn("capture frame", "err", frameErr)
+		}
+
+		r.Frame = frame
+
+		path, writeErr := r.artifacts.writeFrame(r.Path, frame)
+		if writeErr != nil {
+			r.log.Warn("write frame", "err", writeErr)
+		}
+
+		if path != "" {
+			r.record.Screenshots = append([]string{path}, r.record.Screenshots...)
+		}
+	}
+
+	if err != nil {
+		screenshot, screenDump, captureErr := r.artifacts.capture(r.Path, err)
+		if captureErr != nil {
+			r.log.Warn("capture artifacts", "err", captureErr)
+		}
+
+		if screenshot != "" {
+			r.record.AddScreenshot(screenshot)
+		}
+
+		r.ScreenDump = screenDump
+	}
+
+	r.Point = r.record.Point
+	r.Match = r.record.Match
+	r.Template = r.record.Template
+	r.Element = r.record.Element
+	r.Screenshots = r.record.Screenshots
+
+	if err != nil {
+		r.log.Error("step failed", "attempts", attempts, "took", r.Duration(), "err", err)
+	} else {
+		r.log.Info("step finished", "attempts", attempts, "took", r.Duration())
+	}
+
+	r.observers.OnStepEnd(r.state, r)
+}
+
+func (r *StepResult) retry(attempt int, err error) {
+	if r == nil {
+		return
+	}
+
+	r.log.Warn("step retry", "attempt", attempt, "err", err)
+	r.observers.OnRetry(r, attempt, err)
+}
+
+func (r *StepResult) Duration() time.Duration {
+	return r.EndAt.Sub(r.StartAt)
+}
+
+func stateFrom(ctx context.Context) *FlowState {
+	state, _ := ctx.Value(stateKey{}).(*FlowState)
+
+	return state
+}
+
+func (s *FlowState) rewind() func() {
+	if s == nil {
+		return func() {}
+	}
+
+	steps, completed := s.StepsCount, s.CompletedSteps
+	results, subFlows := len(s.Steps), len(s.SubFlows)
+
+	return func() {
+		s.StepsCount, s.CompletedSteps = steps, completed
+		s.Steps = s.Steps[:results]
+		s.SubFlows = s.SubFlows[:subFlows]
+	}
+}
+
+func (s *FlowState) TopSteps() []*StepResult {
+	if s == nil || len(s.Steps) == 0 {
+		return nil
+	}
+
+	depth := s.Steps[0].Depth
+
+	for _, step := range s.Steps {
+		depth = min(depth, step.Depth)
+	}
+
+	out := make([]*StepResult, 0, len(s.Steps))
+
+	for _, step := range s.Steps {
+		if step.Depth == depth {
+			out = append(out, step)
+		}
+	}
+
+	return out
+}
+
+func (s *FlowState) Failed() *StepResult {
+	if s == nil {
+		return nil
+	}
+
+	for i := len(s.Steps) - 1; i >= 0; i-- {
+		if s.Steps[i].Err != nil {
+			return s.Steps[i]
+		}
+	}
+
+	return nil
+}
+
+func stepParams(step FlowStep) map[string]string {
+	params := make(map[string]string)
+
+	for {
+		switch s := step.(type) {
+		case *retryAction:
+			params["Retry"] = fmt.Sprintf("%d attempts", max(s.policy.Attempts, 1))
+		case *timeoutAction:
+			params["Timeout"] = s.timeout.String()
+		case *delayAction:
+			if s.before > 0 {
+				params["DelayBefore"] = s.before.String()
+			}
+
+			if s.after > 0 {
+				params["DelayAfter"] = s.after.String()
+			}
+		}
+
+		w, ok := step.(interface{ unwrap() FlowStep })
+		if !ok {
+			break
+		}
+
+		step = w.unwrap()
+	}
+
+	v := reflect.ValueOf(step)
+
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return params
+	}
+
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		value := v.Field(i)
+
+		if !field.IsExported() || value.IsZero() {
+			continue
+		}
+
+		params[field.Name] = formatParam(value)
+	}
+
+	return params
+}
+
+func formatParam(v reflect.Value) string {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+
+		if img, ok := v.Interface().(image.Image); ok {
+			size := img.Bounds().Size()
+
+			return fmt.Sprintf("image %dx%d", size.X, size.Y)
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() == reflect.Func {
+		return "func"
+	}
+
+	return fmt.Sprint(v.Interface())
+}
@@ -0,0 +1,227 @@
+package screenflow
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/merzzzl/screen-flow/device"
+)
+
+type RetryPolicy struct {
+	Attempts   int
+	Delay      time.Duration
+	MaxDelay   time.Duration
+	Multiplier float64
+	Jitter     float64
+	RetryIf    func(err error) bool
+}
+
+type attemptsKey struct{}
+
+type retryKey struct{}
+
+const reconnectRuns = 2
+
+type retryAction struct {
+	step   FlowStep
+	policy *RetryPolicy
+}
+
+func RetryFixed(attempts int, delay time.Duration) *RetryPolicy {
+	return &RetryPolicy{
+		Attempts:   attempts,
+		Delay:      delay,
+		Multiplier: 1,
+	}
+}
+
+func RetryExponential(attempts int, delay, maxDelay time.Duration) *RetryPolicy {
+	return &RetryPolicy{
+		Attempts:   attempts,
+		Delay:      delay,
+		MaxDelay:   maxDelay,
+		Multiplier: 2,
+	}
+}
+
+func RetryOn(targets ...error) func(err error) bool {
+	return func(err error) bool {
+		for _, target := range targets {
+			if errors.Is(err, target) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+func (p *RetryPolicy) WithJitter(jitter float64) *RetryPolicy {
+	out := *p
+	out.Jitter = jitter
+
+	return &out
+}
+
+func (p *RetryPolicy) WithRetryIf(fn func(err error) bool) *RetryPolicy {
+	out := *p
+	out.RetryIf = fn
+
+	return &out
+}
+
+func (p *RetryPolicy) do(ctx context.Context, fn func(ctx context.Context) error) (int, error) {
+	res, _ := ctx.Value(resultKey{}).(*StepResult)
+
+	attempts := max(p.Attempts, 1)
+	rewind := stateFrom(ctx).rewind()
+
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return attempt, nil
+		}
+
+		if attempt >= attempts || ctx.Err() != nil || !p.retryable(err) {
+			return attempt, err
+		}
+
+		res.retry(attempt, err)
+		rewind()
+
+		if sleep(ctx, p.delay(attempt)) != nil {
+			return attempt, err
+		}
+	}
+}
+
+func (p *RetryPolicy) retryable(err error) bool {
+	if p.RetryIf == nil {
+		return true
+	}
+
+	return p.RetryIf(err)
+}
+
+func (p *RetryPolicy) delay(attempt int) time.Duration {
+	delay := float64(p.Delay)
+
+	if p.Multiplier > 1 {
+		for range attempt - 1 {
+			delay *= p.Multiplier
+		}
+	}
+
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+
+	if p.Jitter > 0 {
+		delay += delay * p.Jitter * (rand.Float64()*2 - 1)
+	}
+
+	return time.Duration(max(delay, 0))
+}
+
+func (a *retryAction) Handle(ctx context.Context, conn *device.Conn) error {
+	attempts, err := a.policy.do(ctx, func(ctx context.Context) error {
+		return a.step.Handle(ctx, conn)
+	})
+
+	if out, ok := ctx.Value(attemptsKey{}).(*int); ok {
+		*out = attempts
+	}
+
+	return err
+}
+
+func (a *retryAction) unwrap() FlowStep {
+	return a.step
+}
+
+func Retry(step FlowStep, policy *RetryPolicy) FlowStep {
+	return &retryAction{
+		step:   step,
+		policy: policy,
+	}
+}
+
+func (f *Flow) Retry(policy *RetryPolicy) *Flow {
+	if len(f.steps) == 0 {
+		return f
+	}
+
+	f.steps[len(f.steps)-1] = Retry(f.steps[len(f.steps)-1], policy)
+
+	return f
+}
+
+func (f *Flow) WithRetry(policy *RetryPolicy) *Flow {
+	f.retry = policy
+
+	return f
+}
+
+func (f *Flow) handle(ctx context.Context, conn *device.Conn, step FlowStep) (int, error) {
+	policy, _ := ctx.Value(retryKey{}).(*RetryPolicy)
+
+	if policy != nil && !hasRetry(step) && !isComposite(step) {
+		step = Retry(step, policy)
+	}
+
+	runs := reconnectRuns
+	if p := retryPolicy(step); p != nil {
+		runs = max(p.Attempts, 1)
+	}
+
+	rewind := stateFrom(ctx).rewind()
+
+	var attempts int
+
+	for run := 1; ; run++ {
+		generation := conn.Generation()
+
+		n := 1
+		err := step.Handle(context.WithValue(ctx, attemptsKey{}, &n), conn)
+		attempts += n
+
+		if err == nil || ctx.Err() != nil || conn.Generation() == generation || run >= runs {
+			return attempts, err
+		}
+
+		if conn.WaitReady(ctx) != nil {
+			return attempts, err
+		}
+
+		res, _ := ctx.Value(resultKey{}).(*StepResult)
+		res.retry(attempts, err)
+		rewind()
+	}
+}
+
+func retryPolicy(step FlowStep) *RetryPolicy {
+	for {
+		if r, ok := step.(*retryAction); ok {
+			return r.policy
+		}
+
+		w, ok := step.(interface{ unwrap() FlowStep })
+		if !ok {
+			return nil
+		}
+
+		step = w.unwrap()
+	}
+}
+
+func isComposite(step FlowStep) bool {
+	_, ok := step.(interface{ composite() })
+
+	return ok
+}
+
+func hasRetry(step FlowStep) bool {
+	return retryPolicy(step) != nil
+}
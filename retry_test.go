@@ -0,0 +1,164 @@
+package screenflow
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/merzzzl/screen-flow/device"
+)
+
+var errFlaky = errors.New("flaky")
+
+func flakyStep(failures int) FlowStep {
+	var calls int
+
+	return ActionFunc(func(context.Context, *device.Conn) error {
+		calls++
+
+		if calls <= failures {
+			return errFlaky
+		}
+
+		return nil
+	})
+}
+
+func TestRetriedSubFlow(t *testing.T) {
+	tests := []struct {
+		name     string
+		flow     func() *Flow
+		attempts int
+	}{
+		{
+			name: "flow default applies to sub-flow steps",
+			flow: func() *Flow {
+				sub := NewFlow().WithName("sub").Load([]FlowStep{flakyStep(1)})
+
+				return NewFlow().WithRetry(RetryFixed(3, 0)).Load([]FlowStep{sub})
+			},
+			attempts: 2,
+		},
+		{
+			name: "explicit retry re-runs the sub-flow",
+			flow: func() *Flow {
+				sub := NewFlow().WithName("sub").Load([]FlowStep{flakyStep(1)})
+
+				return NewFlow().Load([]FlowStep{Retry(sub, RetryFixed(3, 0))})
+			},
+			attempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, err := tt.flow().RunOn(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("RunOn() error = %v", err)
+			}
+
+			if len(state.SubFlows) != 1 {
+				t.Fatalf("SubFlows = %d, want 1", len(state.SubFlows))
+			}
+
+			sub := state.SubFlows[0]
+
+			if sub.Err != nil {
+				t.Errorf("sub-flow Err = %v, want nil", sub.Err)
+			}
+
+			if len(sub.Steps) != 1 || sub.Steps[0].Attempts != tt.attempts {
+				t.Errorf("sub-flow steps = %d, want 1 with %d attempts", len(sub.Steps), tt.attempts)
+			}
+
+			if state.StepsCount != 1 || state.CompletedSteps != 1 {
+				t.Errorf("counters = %d/%d, want 1/1", state.CompletedSteps, state.StepsCount)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  *RetryPolicy
+		attempt int
+		want    time.Duration
+	}{
+		{name: "fixed first", policy: RetryFixed(3, time.Second), attempt: 1, want: time.Second},
+		{name: "fixed later", policy: RetryFixed(3, time.Second), attempt: 4, want: time.Second},
+		{name: "exponential first", policy: RetryExponential(5, time.Second, 0), attempt: 1, want: time.Second},
+		{name: "exponential third", policy: RetryExponential(5, time.Second, 0), attempt: 3, want: 4 * time.Second},
+		{name: "exponential capped", policy: RetryExponential(5, time.Second, 3*time.Second), attempt: 4, want: 3 * time.Second},
+		{name: "custom multiplier", policy: &RetryPolicy{Delay: 100 * time.Millisecond, Multiplier: 3}, attempt: 3, want: 900 * time.Millisecond},
+		{name: "zero delay", policy: RetryFixed(3, 0), attempt: 2, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.delay(tt.attempt); got != tt.want {
+				t.Errorf("delay(%d) = %s, want %s", tt.attempt, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyJitter(t *testing.T) {
+	policy := RetryFixed(3, time.Second).WithJitter(0.5)
+
+	for range 100 {
+		got := policy.delay(1)
+		if got < 500*time.Millisecond || got > 1500*time.Millisecond {
+			t.Fatalf("delay(1) = %s, want within [500ms, 1.5s]", got)
+		}
+	}
+}
+
+func TestRetryPolicyDo(t *testing.T) {
+	errFatal := errors.New("fatal")
+
+	tests := []struct {
+		name     string
+		policy   *RetryPolicy
+		errs     []error
+		attempts int
+		wantErr  error
+	}{
+		{name: "first try", policy: RetryFixed(3, 0), errs: nil, attempts: 1},
+		{name: "recovers", policy: RetryFixed(3, 0), errs: []error{errFlaky, errFlaky}, attempts: 3},
+		{name: "exhausted", policy: RetryFixed(2, 0), errs: []error{errFlaky, errFlaky, errFlaky}, attempts: 2, wantErr: errFlaky},
+		{name: "zero attempts runs once", policy: RetryFixed(0, 0), errs: []error{errFlaky}, attempts: 1, wantErr: errFlaky},
+		{
+			name:     "not retryable",
+			policy:   RetryFixed(3, 0).WithRetryIf(RetryOn(errFlaky)),
+			errs:     []error{errFatal},
+			attempts: 1,
+			wantErr:  errFatal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+
+			attempts, err := tt.policy.do(context.Background(), func(context.Context) error {
+				calls++
+
+				if calls <= len(tt.errs) {
+					return tt.errs[calls-1]
+				}
+
+				return nil
+			})
+
+			if attempts != tt.attempts || calls != tt.attempts {
+				t.Errorf("attempts = %d, calls = %d, want %d", attempts, calls, tt.attempts)
+			}
+
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
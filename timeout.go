@@ -0,0 +1,63 @@
+package screenflow
+
+import (
+	"context"
+	"time"
+
+	"github.com/merzzzl/screen-flow/device"
+)
+
+type timeoutAction struct {
+	step    FlowStep
+	timeout time.Duration
+}
+
+func (a *timeoutAction) Handle(ctx context.Context, conn *device.Conn) error {
+	stepCtx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
+	err := a.step.Handle(stepCtx, conn)
+	if err != nil && ctx.Err() == nil && stepCtx.Err() != nil {
+		te := &TimeoutError{
+			Index:   -1,
+			Kind:    stepKind(a.step),
+			Timeout: a.timeout,
+			Err:     err,
+		}
+
+		if res, ok := ctx.Value(resultKey{}).(*StepResult); ok {
+			te.Index, te.Path = res.Index, res.Path
+		}
+
+		return te
+	}
+
+	return err
+}
+
+func (a *timeoutAction) unwrap() FlowStep {
+	return a.step
+}
+
+func Timeout(step FlowStep, timeout time.Duration) FlowStep {
+	return &timeoutAction{
+		step:    step,
+		timeout: timeout,
+	}
+}
+
+func (f *Flow) Timeout(timeout time.Duration) *Flow {
+	if len(f.steps) == 0 {
+		return f
+	}
+
+	f.steps[len(f.steps)-1] = Timeout(f.steps[len(f.steps)-1], timeout)
+
+	return f
+}
+
+func (f *Flow) WithTimeout(timeout time.Duration) *Flow {
+	f.timeout = timeout
+
+	return f
+}
@@ -0,0 +1,71 @@
+package screenflow
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/merzzzl/screen-flow/actions"
+)
+
+func Vars(ctx context.Context) *actions.Vars {
+	return actions.VarsFromContext(ctx)
+}
+
+func (f *Flow) WithVars(values map[string]any) *Flow {
+	if f.vars == nil {
+		f.vars = make(map[string]any, len(values))
+	}
+
+	maps.Copy(f.vars, values)
+
+	return f
+}
+
+func (f *Flow) Bind(params map[string]any) *Flow {
+	out := *f
+	out.steps = slices.Clone(f.steps)
+	out.vars = maps.Clone(f.vars)
+	out.observers = slices.Clone(f.observers)
+
+	return out.WithVars(params)
+}
+
+func (f *Flow) bindVars(ctx context.Context) (context.Context, *actions.Vars, func()) {
+	vars := actions.VarsFromContext(ctx)
+
+	if vars == nil {
+		vars = actions.NewVars()
+		ctx = actions.WithVars(ctx, vars)
+
+		for name, value := range f.vars {
+			vars.Set(name, value)
+		}
+
+		return ctx, vars, func() {}
+	}
+
+	type saved struct {
+		value any
+		ok    bool
+	}
+
+	prev := make(map[string]saved, len(f.vars))
+
+	for name, value := range f.vars {
+		old, ok := vars.Get(name)
+		prev[name] = saved{value: old, ok: ok}
+
+		vars.Set(name, value)
+	}
+
+	return ctx, vars, func() {
+		for name, old := range prev {
+			if old.ok {
+				vars.Set(name, old.value)
+			} else {
+				vars.Delete(name)
+			}
+		}
+	}
+}
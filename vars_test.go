@@ -0,0 +1,101 @@
+package screenflow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/merzzzl/screen-flow/actions"
+)
+
+func TestBindVars(t *testing.T) {
+	tests := []struct {
+		name   string
+		parent map[string]any
+		flow   map[string]any
+		during map[string]any
+		after  map[string]any
+	}{
+		{
+			name:   "no parent store",
+			flow:   map[string]any{"user": "alice"},
+			during: map[string]any{"user": "alice"},
+		},
+		{
+			name:   "overrides and restores",
+			parent: map[string]any{"user": "alice", "env": "prod"},
+			flow:   map[string]any{"user": "bob"},
+			during: map[string]any{"user": "bob", "env": "prod"},
+			after:  map[string]any{"user": "alice", "env": "prod"},
+		},
+		{
+			name:   "removes added names",
+			parent: map[string]any{"env": "prod"},
+			flow:   map[string]any{"otp": "1234"},
+			during: map[string]any{"env": "prod", "otp": "1234"},
+			after:  map[string]any{"env": "prod"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var parent *actions.Vars
+
+			if tt.parent != nil {
+				parent = actions.NewVars()
+
+				for name, value := range tt.parent {
+					parent.Set(name, value)
+				}
+
+				ctx = actions.WithVars(ctx, parent)
+			}
+
+			ctx, vars, restore := NewFlow().WithVars(tt.flow).bindVars(ctx)
+
+			if Vars(ctx) != vars {
+				t.Fatalf("Vars(ctx) is not the bound store")
+			}
+
+			assertVars(t, vars, tt.during)
+
+			restore()
+
+			if parent != nil {
+				assertVars(t, parent, tt.after)
+			}
+		})
+	}
+}
+
+func TestBindCopiesFlow(t *testing.T) {
+	base := NewFlow().WithVars(map[string]any{"user": "alice"})
+	bound := base.Bind(map[string]any{"user": "bob"})
+
+	if base.vars["user"] != "alice" {
+		t.Errorf("base user = %v, want alice", base.vars["user"])
+	}
+
+	if bound.vars["user"] != "bob" {
+		t.Errorf("bound user = %v, want bob", bound.vars["user"])
+	}
+}
+
+func assertVars(t *testing.T, vars *actions.Vars, want map[string]any) {
+	t.Helper()
+
+	got := vars.Snapshot()
+
+	if len(got) != len(want) {
+		t.Errorf("vars = %v, want %v", got, want)
+
+		return
+	}
+
+	for name, value := range want {
+		if got[name] != value {
+			t.Errorf("vars[%q] = %v, want %v", name, got[name], value)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package vision
+
+import (
+	"sync"
+
+	"gocv.io/x/gocv"
+)
+
+type FrameKind int
+
+const (
+	FrameScaled FrameKind = iota
+	FrameFull
+	FrameInfo
+)
+
+type DropPolicy int
+
+const (
+	DropOldest DropPolicy = iota
+	DropNewest
+	Block
+)
+
+type SubscribeOptions struct {
+	Kind   FrameKind
+	Buffer int
+	Drop   DropPolicy
+}
+
+type Subscription struct {
+	pipe   *Pipe
+	opts   SubscribeOptions
+	c      chan Frame
+	done   chan struct{}
+	once   sync.Once
+	mu     sync.Mutex
+	closed bool
+}
+
+type frameHandler struct {
+	fn func(Frame)
+}
+
+func (p *Pipe) Subscribe(opts SubscribeOptions) *Subscription {
+	s := &Subscription{
+		pipe: p,
+		opts: opts,
+		c:    make(chan Frame, max(opts.Buffer, 1)),
+		done: make(chan struct{}),
+	}
+
+	p.mu.Lock()
+	p.subs[s] = struct{}{}
+	p.mu.Unlock()
+
+	select {
+	case <-p.closed:
+		s.Close()
+	default:
+	}
+
+	return s
+}
+
+func (s *Subscription) Frames() <-chan Frame {
+	return s.c
+}
+
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		close(s.done)
+
+		s.pipe.mu.Lock()
+		delete(s.pipe.subs, s)
+		s.pipe.mu.Unlock()
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.closed = true
+
+		for len(s.c) > 0 {
+			f := <-s.c
+			f.Close()
+		}
+
+		close(s.c)
+	})
+}
+
+func (s *Subscription) deliver(info Frame, full, scaled gocv.Mat) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	f := info
+	f.owned = true
+
+	switch s.opts.Kind {
+	case FrameScaled:
+		f.mat = scaled.Clone()
+	case FrameFull:
+		f.mat = full.Clone()
+		f.Scale = 1
+	case FrameInfo:
+		f.owned = false
+	}
+
+	switch s.opts.Drop {
+	case Block:
+		select {
+		case s.c <- f:
+		case <-s.done:
+			f.Close()
+		case <-s.pipe.closed:
+			f.Close()
+		}
+	case DropNewest:
+		select {
+		case s.c <- f:
+		default:
+			f.Close()
+		}
+	default:
+		for {
+			select {
+			case s.c <- f:
+				return
+			default:
+			}
+
+			select {
+			case old := <-s.c:
+				old.Close()
+			default:
+			}
+		}
+	}
+}
+
+func (p *Pipe) OnFrame(fn func(Frame)) func() {
+	h := &frameHandler{fn: fn}
+
+	p.mu.Lock()
+	p.handlers[h] = struct{}{}
+	p.mu.Unlock()
+
+	return func() {
+		p.mu.Lock()
+		delete(p.handlers, h)
+		p.mu.Unlock()
+	}
+}
+
+func (p *Pipe) publish(info Frame, full, scaled gocv.Mat) {
+	p.mu.Lock()
+	handlers := make([]*frameHandler, 0, len(p.handlers))
+	subs := make([]*Subscription, 0, len(p.subs))
+
+	for h := range p.handlers {
+		handlers = append(handlers, h)
+	}
+
+	for s := range p.subs {
+		subs = append(subs, s)
+	}
+	p.mu.Unlock()
+
+	if len(handlers) > 0 {
+		f := info
+		f.mat = scaled
+		f.lease = &frameLease{}
+
+		for _, h := range handlers {
+			h.fn(f)
+		}
+
+		f.lease.release()
+	}
+
+	for _, s := range subs {
+		s.deliver(info, full, scaled)
+	}
+}
+
+func (p *Pipe) closeSubs() {
+	p.mu.Lock()
+	subs := make([]*Subscription, 0, len(p.subs))
+
+	for s := range p.subs {
+		subs = append(subs, s)
+	}
+	p.mu.Unlock()
+
+	for _, s := range subs {
+		s.Close()
+	}
+}
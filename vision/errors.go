@@ -0,0 +1,9 @@
+package vision
+
+import "errors"
+
+var ErrClosed = errors.New("vision pipe closed")
+
+var ErrNoFrame = errors.New("no frame")
+
+var ErrNoTemplates = errors.New("no templates")
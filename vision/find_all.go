@@ -0,0 +1,114 @@
+package vision
+
+import (
+	"image"
+	"sort"
+	"time"
+
+	"gocv.io/x/gocv"
+)
+
+const (
+	tmThreshold  = 0.75
+	nmsThreshold = 0.3
+)
+
+type candidate struct {
+	rect  image.Rectangle
+	score float32
+}
+
+func findAllTM(src, tpl gocv.Mat, threshold float64) ([]candidate, time.Duration) {
+	if src.Empty() || tpl.Empty() || tpl.Rows() > src.Rows() || tpl.Cols() > src.Cols() {
+		return nil, 0
+	}
+
+	startTime := time.Now()
+
+	srcGray := gocv.NewMat()
+	tplGray := gocv.NewMat()
+
+	defer srcGray.Close()
+	defer tplGray.Close()
+
+	if src.Channels() == 3 {
+		gocv.CvtColor(src, &srcGray, gocv.ColorBGRToGray)
+	} else {
+		_ = src.CopyTo(&srcGray)
+	}
+
+	if tpl.Channels() == 3 {
+		gocv.CvtColor(tpl, &tplGray, gocv.ColorBGRToGray)
+	} else {
+		_ = tpl.CopyTo(&tplGray)
+	}
+
+	res := gocv.NewMatWithSize(srcGray.Rows()-tplGray.Rows()+1, srcGray.Cols()-tplGray.Cols()+1, gocv.MatTypeCV32F)
+	defer res.Close()
+
+	mask := gocv.NewMat()
+	defer mask.Close()
+
+	gocv.MatchTemplate(srcGray, tplGray, &res, gocv.TmCcoeffNormed, mask)
+
+	scores, err := res.DataPtrFloat32()
+	if err != nil {
+		return nil, time.Since(startTime)
+	}
+
+	var (
+		rects []image.Rectangle
+		vals  []float32
+		cols  = res.Cols()
+	)
+
+	for i, v := range scores {
+		if float64(v) < threshold {
+			continue
+		}
+
+		x, y := i%cols, i/cols
+
+		rects = append(rects, image.Rect(x, y, x+tplGray.Cols(), y+tplGray.Rows()))
+		vals = append(vals, v)
+	}
+
+	if len(rects) == 0 {
+		return nil, time.Since(startTime)
+	}
+
+	keep := gocv.NMSBoxes(rects, vals, float32(threshold), nmsThreshold)
+	out := make([]candidate, 0, len(keep))
+
+	for _, i := range keep {
+		out = append(out, candidate{rect: rects[i], score: vals[i]})
+	}
+
+	sortReadingOrder(out)
+
+	return out, time.Since(startTime)
+}
+
+func sortReadingOrder(c []candidate) {
+	sort.Slice(c, func(i, j int) bool {
+		if c[i].rect.Min.Y != c[j].rect.Min.Y {
+			return c[i].rect.Min.Y < c[j].rect.Min.Y
+		}
+
+		return c[i].rect.Min.X < c[j].rect.Min.X
+	})
+
+	for start := 0; start < len(c); {
+		end := start + 1
+
+		for end < len(c) && c[end].rect.Min.Y-c[start].rect.Min.Y < c[start].rect.Dy()/2 {
+			end++
+		}
+
+		row := c[start:end]
+
+		sort.Slice(row, func(i, j int) bool { return row[i].rect.Min.X < row[j].rect.Min.X })
+
+		start = end
+	}
+}
@@ -0,0 +1,71 @@
+package vision
+
+import (
+	"image"
+	"slices"
+	"testing"
+)
+
+func TestSortReadingOrder(t *testing.T) {
+	box := func(x, y int) candidate {
+		return candidate{rect: image.Rect(x, y, x+40, y+20)}
+	}
+
+	tests := []struct {
+		name string
+		in   []candidate
+		want []image.Point
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "single row",
+			in:   []candidate{box(200, 10), box(0, 10), box(100, 10)},
+			want: []image.Point{{0, 10}, {100, 10}, {200, 10}},
+		},
+		{
+			name: "rows top to bottom",
+			in:   []candidate{box(0, 100), box(50, 0), box(0, 0)},
+			want: []image.Point{{0, 0}, {50, 0}, {0, 100}},
+		},
+		{
+			name: "jitter within half height stays in row",
+			in:   []candidate{box(100, 0), box(0, 9), box(200, 4)},
+			want: []image.Point{{0, 9}, {100, 0}, {200, 4}},
+		},
+		{
+			name: "offset beyond half height starts a new row",
+			in:   []candidate{box(0, 10), box(100, 0)},
+			want: []image.Point{{100, 0}, {0, 10}},
+		},
+		{
+			name: "score order from suppression",
+			in: []candidate{
+				{rect: image.Rect(100, 50, 140, 70), score: 0.99},
+				{rect: image.Rect(0, 0, 40, 20), score: 0.95},
+				{rect: image.Rect(0, 50, 40, 70), score: 0.9},
+				{rect: image.Rect(100, 2, 140, 22), score: 0.85},
+			},
+			want: []image.Point{{0, 0}, {100, 2}, {0, 50}, {100, 50}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := slices.Clone(tt.in)
+
+			sortReadingOrder(c)
+
+			got := make([]image.Point, 0, len(c))
+
+			for _, v := range c {
+				got = append(got, v.rect.Min)
+			}
+
+			if !slices.Equal(got, tt.want) && len(got)+len(tt.want) > 0 {
+				t.Errorf("order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -35,6 +35,8 @@ type Result struct {
 	descSrc gocv.Mat
 	descTpl gocv.Mat
 	best    image.Point
+	rect    image.Rectangle
+	score   float64
 }
 
 func findPoint(src, tpl gocv.Mat, algo Algorithm) (*Result, bool) {
@@ -69,11 +71,13 @@ func findPoint(src, tpl gocv.Mat, algo Algorithm) (*Result, bool) {
 
 	switch algo {
 	case AlgorithmTM:
-		pt, ok := findPointTM(srcGray, tplGray)
+		rect, score := findPointTM(srcGray, tplGray)
 
 		return &Result{
 			algo:    algo,
-			best:    pt,
+			best:    rectCenter(rect),
+			rect:    rect,
+			score:   score,
 			src:     src.Clone(),
 			tpl:     tpl.Clone(),
 			kpSrc:   []gocv.KeyPoint{},
@@ -82,7 +86,7 @@ func findPoint(src, tpl gocv.Mat, algo Algorithm) (*Result, bool) {
 			descTpl: gocv.NewMat(),
 			dur:     time.Since(startTime),
 			matches: []gocv.DMatch{},
-		}, ok
+		}, score > tmThreshold
 	case AlgorithmSIFT:
 		kpSrc, kpTpl, descSrc, descTpl = dSIFT(srcGray, tplGray)
 	case AlgorithmORB:
@@ -135,12 +139,7 @@ func findPoint(src, tpl gocv.Mat, algo Algorithm) (*Result, bool) {
 		good = good[:50]
 	}
 
-	pts := make([]image.Point, len(good))
-
-	for i, g := range good {
-		p := kpSrc[g.TrainIdx]
-		pts[i] = image.Pt(int(float64(p.X)), int(float64(p.Y)+0.5))
-	}
+	rect, score := locateTemplate(kpSrc, kpTpl, good, tplGray.Cols(), tplGray.Rows())
 
 	return &Result{
 		algo:    algo,
@@ -152,20 +151,22 @@ func findPoint(src, tpl gocv.Mat, algo Algorithm) (*Result, bool) {
 		descTpl: descTpl.Clone(),
 		dur:     time.Since(startTime),
 		matches: good,
-		best:    pts[0],
+		best:    rectCenter(rect),
+		rect:    rect,
+		score:   score,
 	}, true
 }
 
-func findPointTM(srcGray, tplGray gocv.Mat) (image.Point, bool) {
+func findPointTM(srcGray, tplGray gocv.Mat) (image.Rectangle, float64) {
 	res := gocv.NewMatWithSize(srcGray.Rows()-tplGray.Rows()+1, srcGray.Cols()-tplGray.Cols()+1, gocv.MatTypeCV32F)
 	defer res.Close()
 
 	gocv.MatchTemplate(srcGray, tplGray, &res, gocv.TmCcoeffNormed, gocv.NewMat())
 
 	_, maxVal, _, maxLoc := gocv.MinMaxLoc(res)
-	center := image.Point{X: maxLoc.X + tplGray.Cols()/2, Y: maxLoc.Y + tplGray.Rows()/2}
+	rect := image.Rect(maxLoc.X, maxLoc.Y, maxLoc.X+tplGray.Cols(), maxLoc.Y+tplGray.Rows())
 
-	return center, maxVal > 0.75
+	return rect, float64(maxVal)
 }
 
 func (r *Result) Close() {
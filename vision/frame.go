@@ -0,0 +1,102 @@
+package vision
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"sync"
+	"time"
+
+	"gocv.io/x/gocv"
+)
+
+type Frame struct {
+	Seq    uint64
+	At     time.Time
+	Change float64
+	Static int
+	Scale  float64
+	mat    gocv.Mat
+	owned  bool
+	lease  *frameLease
+}
+
+type frameLease struct {
+	mu       sync.RWMutex
+	released bool
+}
+
+type rawFrame struct {
+	mu   sync.RWMutex
+	data []byte
+	at   time.Time
+}
+
+func (f Frame) Image() (image.Image, error) {
+	if f.lease != nil {
+		f.lease.mu.RLock()
+		defer f.lease.mu.RUnlock()
+
+		if f.lease.released {
+			return nil, ErrNoFrame
+		}
+	}
+
+	if f.mat.Closed() || f.mat.Empty() {
+		return nil, ErrNoFrame
+	}
+
+	img, err := f.mat.ToImage()
+	if err != nil {
+		return nil, fmt.Errorf("convert to image: %w", err)
+	}
+
+	return img, nil
+}
+
+func (f Frame) Close() {
+	if f.owned {
+		_ = f.mat.Close()
+	}
+}
+
+func (p *Pipe) Latest() (image.Image, time.Time, error) {
+	raw := p.latest.Load()
+	if raw == nil {
+		return nil, time.Time{}, ErrNoFrame
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, p.w, p.h))
+
+	raw.mu.RLock()
+	defer raw.mu.RUnlock()
+
+	for i, j := 0, 0; i+2 < len(raw.data); i, j = i+3, j+4 {
+		img.Pix[j] = raw.data[i+2]
+		img.Pix[j+1] = raw.data[i+1]
+		img.Pix[j+2] = raw.data[i]
+		img.Pix[j+3] = 0xff
+	}
+
+	return img, raw.at, nil
+}
+
+func (p *Pipe) Screenshot(ctx context.Context) (image.Image, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-p.closed:
+		return nil, ErrClosed
+	case <-p.ready:
+	}
+
+	img, _, err := p.Latest()
+
+	return img, err
+}
+
+func (l *frameLease) release() {
+	l.mu.Lock()
+	l.released = true
+	l.mu.Unlock()
+}
@@ -0,0 +1,120 @@
+package vision
+
+import (
+	"image"
+	"math"
+
+	"gocv.io/x/gocv"
+)
+
+const minHomographyPairs = 4
+
+func locateTemplate(kpSrc, kpTpl []gocv.KeyPoint, good []gocv.DMatch, w, h int) (image.Rectangle, float64) {
+	best := good[0]
+	fallback := offsetRect(kpSrc[best.TrainIdx], kpTpl[best.QueryIdx], w, h)
+	fallbackScore := min(float64(len(good))/float64(max(len(kpTpl), 1)), 1)
+
+	if len(good) < minHomographyPairs {
+		return fallback, fallbackScore
+	}
+
+	srcPts := make([]gocv.Point2f, 0, len(good))
+	tplPts := make([]gocv.Point2f, 0, len(good))
+
+	for _, m := range good {
+		srcPts = append(srcPts, gocv.Point2f{X: float32(kpSrc[m.TrainIdx].X), Y: float32(kpSrc[m.TrainIdx].Y)})
+		tplPts = append(tplPts, gocv.Point2f{X: float32(kpTpl[m.QueryIdx].X), Y: float32(kpTpl[m.QueryIdx].Y)})
+	}
+
+	quad, inliers, ok := projectCorners(tplPts, srcPts, w, h)
+	if !ok {
+		return fallback, fallbackScore
+	}
+
+	rect := boundingRect(quad)
+	area := float64(rect.Dx() * rect.Dy())
+	tplArea := float64(w * h)
+
+	if area < tplArea/10 || area > tplArea*10 {
+		return fallback, fallbackScore
+	}
+
+	return rect, float64(inliers) / float64(len(good))
+}
+
+func projectCorners(tplPts, srcPts []gocv.Point2f, w, h int) ([]gocv.Point2f, int, bool) {
+	tplVec := gocv.NewPoint2fVectorFromPoints(tplPts)
+	defer tplVec.Close()
+
+	srcVec := gocv.NewPoint2fVectorFromPoints(srcPts)
+	defer srcVec.Close()
+
+	tplMat := gocv.NewMatFromPoint2fVector(tplVec, true)
+	defer tplMat.Close()
+
+	srcMat := gocv.NewMatFromPoint2fVector(srcVec, true)
+	defer srcMat.Close()
+
+	mask := gocv.NewMat()
+	defer mask.Close()
+
+	homography := gocv.FindHomography(tplMat, srcMat, gocv.HomographyMethodRANSAC, 3, &mask, 2000, 0.995)
+	defer homography.Close()
+
+	if homography.Empty() {
+		return nil, 0, false
+	}
+
+	cornersVec := gocv.NewPoint2fVectorFromPoints([]gocv.Point2f{
+		{X: 0, Y: 0},
+		{X: float32(w), Y: 0},
+		{X: float32(w), Y: float32(h)},
+		{X: 0, Y: float32(h)},
+	})
+	defer cornersVec.Close()
+
+	corners := gocv.NewMatFromPoint2fVector(cornersVec, true)
+	defer corners.Close()
+
+	projected := gocv.NewMat()
+	defer projected.Close()
+
+	if err := gocv.PerspectiveTransform(corners, &projected, homography); err != nil {
+		return nil, 0, false
+	}
+
+	quadVec := gocv.NewPoint2fVectorFromMat(projected)
+	defer quadVec.Close()
+
+	quad := quadVec.ToPoints()
+	if len(quad) != 4 {
+		return nil, 0, false
+	}
+
+	return quad, gocv.CountNonZero(mask), true
+}
+
+func boundingRect(pts []gocv.Point2f) image.Rectangle {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+
+	for _, p := range pts {
+		minX = math.Min(minX, float64(p.X))
+		minY = math.Min(minY, float64(p.Y))
+		maxX = math.Max(maxX, float64(p.X))
+		maxY = math.Max(maxY, float64(p.Y))
+	}
+
+	return image.Rect(int(math.Floor(minX)), int(math.Floor(minY)), int(math.Ceil(maxX)), int(math.Ceil(maxY)))
+}
+
+func offsetRect(src, tpl gocv.KeyPoint, w, h int) image.Rectangle {
+	x := int(math.Round(src.X - tpl.X))
+	y := int(math.Round(src.Y - tpl.Y))
+
+	return image.Rect(x, y, x+w, y+h)
+}
+
+func rectCenter(r image.Rectangle) image.Point {
+	return image.Pt((r.Min.X+r.Max.X)/2, (r.Min.Y+r.Max.Y)/2)
+}
@@ -0,0 +1,77 @@
+package vision
+
+import (
+	"image"
+	"time"
+)
+
+type Match struct {
+	Point     image.Point
+	Rect      image.Rectangle
+	Score     float64
+	Algorithm Algorithm
+	Duration  time.Duration
+	Pairs     []KeyPair
+	Frame     image.Image
+}
+
+type KeyPair struct {
+	Src image.Point
+	Tpl image.Point
+}
+
+func newMatch(res *Result, point image.Point, scale float64) *Match {
+	m := &Match{
+		Point:     point,
+		Rect:      restoreRect(res.rect, scale),
+		Score:     res.score,
+		Algorithm: res.algo,
+		Duration:  res.dur,
+		Pairs:     make([]KeyPair, 0, len(res.matches)),
+	}
+
+	for _, dm := range res.matches {
+		if dm.TrainIdx >= len(res.kpSrc) || dm.QueryIdx >= len(res.kpTpl) {
+			continue
+		}
+
+		src := res.kpSrc[dm.TrainIdx]
+		tpl := res.kpTpl[dm.QueryIdx]
+
+		m.Pairs = append(m.Pairs, KeyPair{
+			Src: restorePoint(image.Pt(int(src.X), int(src.Y)), scale),
+			Tpl: restorePoint(image.Pt(int(tpl.X), int(tpl.Y)), scale),
+		})
+	}
+
+	return m
+}
+
+func (a Algorithm) String() string {
+	switch a {
+	case AlgorithmSIFT:
+		return "SIFT"
+	case AlgorithmTM:
+		return "TM"
+	case AlgorithmORB:
+		return "ORB"
+	case AlgorithmAKAZE:
+		return "AKAZE"
+	case AlgorithmBRISK:
+		return "BRISK"
+	case AlgorithmFAST:
+		return "FAST"
+	case AlgorithmKAZE:
+		return "KAZE"
+	case AlgorithmSURF:
+		return "SURF"
+	case AlgorithmAGAST:
+		return "AGAST"
+	case AlgorithmGFTT:
+		return "GFTT"
+	case AlgorithmBRIEF:
+		return "BRIEF"
+	default:
+		return "unknown"
+	}
+}
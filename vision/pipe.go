@@ -5,141 +5,492 @@ import (
 	"fmt"
 	"image"
 	"io"
+	"log/slog"
+	"slices"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"gocv.io/x/gocv"
 )
 
+const (
+	staticFrames = 30
+	pointJitter  = 4
+)
+
 type Pipe struct {
-	tmpl    atomic.Pointer[gocv.Mat]
-	success atomic.Uint32
-	point   chan image.Point
-	algo    Algorithm
-	r       io.Reader
-	h       int
-	w       int
+	latest   atomic.Pointer[rawFrame]
+	algo     Algorithm
+	r        io.Reader
+	h        int
+	w        int
+	mu       sync.Mutex
+	subs     map[*Subscription]struct{}
+	handlers map[*frameHandler]struct{}
+	ready    chan struct{}
+	once     sync.Once
+	closed   chan struct{}
+	closing  sync.Once
+	log      *slog.Logger
 }
 
 func NewPipe(stream io.Reader, w, h int, algo Algorithm) *Pipe {
 	return &Pipe{
-		r:       stream,
-		w:       w,
-		h:       h,
-		point:   make(chan image.Point),
-		tmpl:    atomic.Pointer[gocv.Mat]{},
-		success: atomic.Uint32{},
-		algo:    algo,
+		r:        stream,
+		w:        w,
+		h:        h,
+		algo:     algo,
+		subs:     make(map[*Subscription]struct{}),
+		handlers: make(map[*frameHandler]struct{}),
+		ready:    make(chan struct{}),
+		closed:   make(chan struct{}),
+		log:      slog.Default(),
+	}
+}
+
+func (p *Pipe) WithLogger(logger *slog.Logger) *Pipe {
+	if logger != nil {
+		p.log = logger
 	}
+
+	return p
 }
 
 func (p *Pipe) Process(ctx context.Context) error {
 	var (
-		static    uint32
-		lastPoint *image.Point
-		prev      *gocv.Mat
-		frame     = make([]byte, p.w*p.h*3)
+		static int
+		seq    uint64
+		prev   *gocv.Mat
+		bufs   = [2]*rawFrame{
+			{data: make([]byte, p.w*p.h*3)},
+			{data: make([]byte, p.w*p.h*3)},
+		}
 	)
 
 	defer func() {
 		if prev != nil {
 			_ = prev.Close()
 		}
-
-		close(p.point)
 	}()
 
 	for ctx.Err() == nil {
-		if _, err := io.ReadFull(p.r, frame); err != nil {
+		raw := bufs[seq%2]
+
+		raw.mu.Lock()
+		_, err := io.ReadFull(p.r, raw.data)
+		now := time.Now()
+		raw.at = now
+		raw.mu.Unlock()
+
+		if err != nil {
 			return fmt.Errorf("read frame: %w", err)
 		}
 
-		next, err := gocv.NewMatFromBytes(p.h, p.w, gocv.MatTypeCV8UC3, frame)
+		p.latest.Store(raw)
+
+		next, err := gocv.NewMatFromBytes(p.h, p.w, gocv.MatTypeCV8UC3, raw.data)
 		if err != nil {
 			return fmt.Errorf("convert to mat: %w", err)
 		}
 
+		p.once.Do(func() {
+			close(p.ready)
+		})
+
 		nextSrc, scale := resizeSrc(next)
-		_ = next.Close()
+		change := 1.0
 
 		if prev != nil {
-			change := calcChangeRatio(*prev, nextSrc)
+			change = calcChangeRatio(*prev, nextSrc)
 
 			if change < 0.10 {
 				static++
 
+				if static == staticFrames+1 {
+					p.log.Debug("screen static", "change", change)
+				}
+
 				if static > 120 {
 					static = 120
 				}
 			} else {
+				if static > staticFrames {
+					p.log.Debug("screen changed", "change", change)
+				}
+
 				static = 0
 			}
 
 			_ = prev.Close()
 		}
 
+		seq++
+
+		p.publish(Frame{
+			Seq:    seq,
+			At:     now,
+			Change: change,
+			Static: static,
+			Scale:  scale,
+		}, next, nextSrc)
+
+		if scale != 1 {
+			_ = next.Close()
+		}
+
 		prev = &nextSrc
+	}
+
+	return nil
+}
+
+func (p *Pipe) Ready() <-chan struct{} {
+	return p.ready
+}
+
+func (p *Pipe) Close() {
+	p.closing.Do(func() {
+		close(p.closed)
+
+		p.closeSubs()
+	})
+}
+
+func (p *Pipe) Find(ctx context.Context, img image.Image) (image.Point, error) {
+	m, err := p.FindMatch(ctx, img)
+	if err != nil {
+		return image.Pt(0, 0), err
+	}
+
+	return m.Point, nil
+}
+
+func (p *Pipe) FindMatch(ctx context.Context, img image.Image) (*Match, error) {
+	obj, err := toMat(img)
+	if err != nil {
+		return nil, fmt.Errorf("convert to mat: %w", err)
+	}
+
+	defer func() {
+		_ = obj.Close()
+	}()
+
+	var (
+		success   int
+		lastPoint *image.Point
+	)
 
-		var res *Result
+	return consume(ctx, p, func(f Frame) (*Match, bool) {
+		if f.Static <= staticFrames {
+			return nil, false
+		}
 
-		if tmpl := p.tmpl.Load(); static > 30 && tmpl != nil {
-			var (
-				ok    bool
-				point image.Point
-			)
+		m, ok := p.match(f, obj)
 
-			tpl := resizeTpl(*tmpl, scale)
-			res, ok = findPoint(nextSrc, tpl, p.algo)
-			_ = tpl.Close()
+		switch {
+		case ok && lastPoint != nil && samePoint(*lastPoint, m.Point):
+			if success >= 5 {
+				p.log.Debug("template matched", "point", m.Point, "score", m.Score, "took", m.Duration)
 
-			if ok {
-				point = restorePoint(res.best, scale)
+				p.attachFrame(m)
+
+				return m, true
 			}
 
-			if ok && lastPoint != nil && lastPoint.X == point.X && lastPoint.Y == point.Y {
-				if p.success.Load() >= 5 {
-					select {
-					case p.point <- point:
-					default:
-					}
-				} else {
-					p.success.Add(1)
-				}
-			} else {
-				p.success.Store(0)
+			success++
+		default:
+			success = 0
+		}
+
+		if ok {
+			lastPoint = &m.Point
+		}
+
+		return nil, false
+	})
+}
+
+func (p *Pipe) FindAny(ctx context.Context, imgs ...image.Image) (int, *Match, error) {
+	if len(imgs) == 0 {
+		return -1, nil, ErrNoTemplates
+	}
+
+	objs := make([]gocv.Mat, 0, len(imgs))
+
+	defer func() {
+		for _, obj := range objs {
+			_ = obj.Close()
+		}
+	}()
+
+	for i, img := range imgs {
+		obj, err := toMat(img)
+		if err != nil {
+			return -1, nil, fmt.Errorf("convert template %d to mat: %w", i, err)
+		}
+
+		objs = append(objs, obj)
+	}
+
+	type hit struct {
+		index int
+		match *Match
+	}
+
+	var (
+		success int
+		last    *hit
+	)
+
+	out, err := consume(ctx, p, func(f Frame) (*hit, bool) {
+		if f.Static <= staticFrames {
+			return nil, false
+		}
+
+		var next *hit
+
+		for i, obj := range objs {
+			if m, ok := p.match(f, obj); ok {
+				next = &hit{index: i, match: m}
+
+				break
 			}
+		}
+
+		switch {
+		case next != nil && last != nil && last.index == next.index && samePoint(last.match.Point, next.match.Point):
+			if success >= 5 {
+				p.log.Debug("template matched", "index", next.index, "point", next.match.Point, "score", next.match.Score, "took", next.match.Duration)
+
+				p.attachFrame(next.match)
 
-			if ok {
-				lastPoint = &point
+				return next, true
 			}
+
+			success++
+		default:
+			success = 0
 		}
 
-		if res != nil {
-			res.Close()
+		if next != nil {
+			last = next
 		}
+
+		return nil, false
+	})
+	if err != nil {
+		return -1, nil, err
 	}
 
-	return nil
+	return out.index, out.match, nil
 }
 
-func (p *Pipe) Find(ctx context.Context, img image.Image) (image.Point, error) {
+func (p *Pipe) FindAll(ctx context.Context, img image.Image, threshold float64) ([]*Match, error) {
 	obj, err := toMat(img)
 	if err != nil {
-		return image.Pt(0, 0), fmt.Errorf("convert to mat: %w", err)
+		return nil, fmt.Errorf("convert to mat: %w", err)
 	}
 
-	p.tmpl.Store(&obj)
-	p.success.Store(0)
-
 	defer func() {
-		p.tmpl.Store(nil)
+		_ = obj.Close()
+	}()
+
+	if threshold <= 0 {
+		threshold = tmThreshold
+	}
+
+	var (
+		success int
+		last    []image.Point
+		seen    bool
+	)
+
+	return consume(ctx, p, func(f Frame) ([]*Match, bool) {
+		if f.Static <= staticFrames {
+			return nil, false
+		}
+
+		matches := p.matchAll(f, obj, threshold)
+		points := make([]image.Point, 0, len(matches))
+
+		for _, m := range matches {
+			points = append(points, m.Point)
+		}
+
+		switch {
+		case seen && slices.Equal(points, last):
+			if success >= 5 {
+				p.log.Debug("templates matched", "count", len(matches))
+
+				p.attachFrame(matches...)
+
+				return matches, true
+			}
+
+			success++
+		default:
+			success = 0
+		}
+
+		last, seen = points, true
+
+		return nil, false
+	})
+}
 
+func (p *Pipe) WaitGone(ctx context.Context, img image.Image, area *image.Rectangle, frames int) error {
+	obj, err := toMat(img)
+	if err != nil {
+		return fmt.Errorf("convert to mat: %w", err)
+	}
+
+	defer func() {
 		_ = obj.Close()
 	}()
 
-	select {
-	case <-ctx.Done():
-		return image.Pt(0, 0), ctx.Err()
-	case point := <-p.point:
-		return point, nil
+	var absent int
+
+	_, err = consume(ctx, p, func(f Frame) (struct{}, bool) {
+		if f.Static <= staticFrames {
+			return struct{}{}, false
+		}
+
+		m, ok := p.match(f, obj)
+		if ok && (area == nil || m.Point.In(*area)) {
+			absent = 0
+
+			return struct{}{}, false
+		}
+
+		absent++
+
+		if absent >= max(frames, 1) {
+			p.log.Debug("template gone", "frames", absent)
+
+			return struct{}{}, true
+		}
+
+		return struct{}{}, false
+	})
+
+	return err
+}
+
+func (p *Pipe) WaitStable(ctx context.Context, threshold float64, minDur time.Duration) error {
+	var since time.Time
+
+	sub := p.Subscribe(SubscribeOptions{Kind: FrameInfo, Buffer: 16, Drop: DropOldest})
+	defer sub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case f, ok := <-sub.Frames():
+			if !ok {
+				return ErrClosed
+			}
+
+			if f.Change >= threshold {
+				since = time.Time{}
+
+				continue
+			}
+
+			if since.IsZero() {
+				since = f.At
+			}
+
+			if f.At.Sub(since) >= minDur {
+				return nil
+			}
+		}
+	}
+}
+
+func (p *Pipe) match(f Frame, obj gocv.Mat) (*Match, bool) {
+	tpl := resizeTpl(obj, f.Scale)
+	res, ok := findPoint(f.mat, tpl, p.algo)
+
+	if f.Scale != 1 {
+		_ = tpl.Close()
+	}
+
+	if res == nil {
+		return nil, false
+	}
+
+	defer res.Close()
+
+	if !ok {
+		return nil, false
+	}
+
+	return newMatch(res, restorePoint(res.best, f.Scale), f.Scale), true
+}
+
+func (p *Pipe) matchAll(f Frame, obj gocv.Mat, threshold float64) []*Match {
+	tpl := resizeTpl(obj, f.Scale)
+	found, dur := findAllTM(f.mat, tpl, threshold)
+
+	if f.Scale != 1 {
+		_ = tpl.Close()
+	}
+
+	out := make([]*Match, 0, len(found))
+
+	for _, c := range found {
+		out = append(out, &Match{
+			Point:     restorePoint(rectCenter(c.rect), f.Scale),
+			Rect:      restoreRect(c.rect, f.Scale),
+			Score:     float64(c.score),
+			Algorithm: AlgorithmTM,
+			Duration:  dur,
+		})
+	}
+
+	return out
+}
+
+func samePoint(a, b image.Point) bool {
+	d := a.Sub(b)
+
+	return max(d.X, -d.X) <= pointJitter && max(d.Y, -d.Y) <= pointJitter
+}
+
+func (p *Pipe) attachFrame(matches ...*Match) {
+	img, _, err := p.Latest()
+	if err != nil {
+		return
+	}
+
+	for _, m := range matches {
+		m.Frame = img
+	}
+}
+
+func consume[T any](ctx context.Context, p *Pipe, fn func(f Frame) (T, bool)) (T, error) {
+	var zero T
+
+	sub := p.Subscribe(SubscribeOptions{Kind: FrameScaled, Buffer: 1, Drop: DropOldest})
+	defer sub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		case f, ok := <-sub.Frames():
+			if !ok {
+				return zero, ErrClosed
+			}
+
+			out, done := fn(f)
+			f.Close()
+
+			if done {
+				return out, nil
+			}
+		}
 	}
 }
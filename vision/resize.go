@@ -54,3 +54,7 @@ func restorePoint(pt image.Point, scale float64) image.Point {
 
 	return pt
 }
+
+func restoreRect(r image.Rectangle, scale float64) image.Rectangle {
+	return image.Rectangle{Min: restorePoint(r.Min, scale), Max: restorePoint(r.Max, scale)}
+}